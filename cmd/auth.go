@@ -2,13 +2,35 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/coollabsio/cloudflare-cli/internal/client"
 	"github.com/coollabsio/cloudflare-cli/internal/config"
+	"github.com/coollabsio/cloudflare-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
+var (
+	authNoVerify   bool
+	authListVerify bool
+)
+
+// authListEntry is one set of credentials shown by auth list. Profile is empty
+// for the top-level credentials of the config file.
+type authListEntry struct {
+	Profile    string `json:"profile"`
+	Active     bool   `json:"active"`
+	AuthMethod string `json:"auth_method"`
+	Credential string `json:"credential"`
+	// Verified is only set with --verify
+	Verified *bool  `json:"verified,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
 var authCmd = &cobra.Command{
 	Use:   "auth",
 	Short: "Authentication commands",
@@ -19,6 +41,9 @@ var authVerifyCmd = &cobra.Command{
 	Short: "Verify API credentials",
 	Long:  `Verify that the configured API credentials are valid and can access the Cloudflare API.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfg.CheckCredentials(); err != nil && !errors.Is(err, config.ErrNoCredentials) {
+			return err
+		}
 		if !cfg.HasCredentials() {
 			return fmt.Errorf(`no credentials configured
 
@@ -51,39 +76,224 @@ var authSaveCmd = &cobra.Command{
 	Short: "Save API token to config file",
 	Long: `Save an API token to the config file (~/.cloudflare/config.yaml).
 
-Example:
-  cf auth save YOUR_API_TOKEN`,
+The token is verified against the API before saving. Use --no-verify to skip
+verification (e.g. in offline or air-gapped environments).
+
+Only the token is replaced; other settings and profiles in the config file
+are kept. With --profile, the token is stored under that profile (which is
+created if needed).
+
+Examples:
+  cf auth save YOUR_API_TOKEN
+  cf auth save YOUR_API_TOKEN --no-verify
+  cf --profile client-a auth save CLIENT_A_TOKEN`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		token := args[0]
 
-		// Create a new config with the token
-		newCfg := &config.Config{
-			APIToken: token,
+		// Replace only the token (of the active profile, if any), keeping the
+		// rest of the config file
+		newCfg := config.ReadFile(config.ResolvePath(cfgFile))
+		newCfg.Profile = cfg.Profile
+		newCfg.APIToken, newCfg.APIKey, newCfg.APIEmail = token, "", ""
+
+		// Verify the token first unless explicitly skipped
+		if authNoVerify {
+			fmt.Fprintln(os.Stderr, "Warning: saving token without verification (--no-verify); it has not been checked against the API")
+		} else {
+			// Verify against the endpoint and headers in use, without saving them
+			if err := verifyCredentials(&config.Config{APIToken: token, APIBaseURL: cfg.APIBaseURL, Headers: cfg.Headers}); err != nil {
+				return fmt.Errorf("token verification failed: %w", err)
+			}
 		}
 
-		// Optionally verify the token first
-		c, err := client.New(newCfg)
-		if err != nil {
-			return err
+		// Save to config file
+		configPath := config.ResolvePath(cfgFile)
+
+		if err := newCfg.Save(configPath); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
 		}
 
-		ctx := context.Background()
-		if err := c.VerifyToken(ctx); err != nil {
-			return fmt.Errorf("token verification failed: %w", err)
+		if cfg.Profile != "" {
+			out.WriteSuccess(fmt.Sprintf("Token saved to profile %s in %s", cfg.Profile, configPath))
+			return nil
 		}
+		out.WriteSuccess(fmt.Sprintf("Token saved to %s", configPath))
+		return nil
+	},
+}
 
-		// Save to config file
-		configPath := cfgFile
-		if configPath == "" {
-			configPath = config.DefaultConfigPath()
+var authRotateCmd = &cobra.Command{
+	Use:   "rotate <new-token>",
+	Short: "Replace the saved API token with a new one",
+	Long: `Replace the API token saved in the config file (in the active profile, if
+any) with a new one.
+
+The new token is verified against the API first; if verification fails, or
+the config file cannot be parsed, the saved token is left untouched. All other
+settings in the config file are kept. Once the new token is saved, revoke the
+old one in the Cloudflare dashboard.
+
+Examples:
+  cf auth rotate NEW_API_TOKEN
+  cf --profile client-a auth rotate NEW_CLIENT_A_TOKEN`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		token := args[0]
+		configPath := config.ResolvePath(cfgFile)
+
+		// Rewriting a file that didn't parse would drop its settings
+		if _, err := config.CheckFile(configPath); err != nil {
+			return fmt.Errorf("config file %s is invalid, not rotating: %w", configPath, err)
+		}
+		newCfg := config.ReadFile(configPath)
+		newCfg.Profile = cfg.Profile
+		oldToken := newCfg.APIToken
+		if cfg.Profile != "" {
+			oldToken = newCfg.Profiles[cfg.Profile].APIToken
+		}
+		if token == oldToken {
+			return fmt.Errorf("the new token is the same as the saved one")
+		}
+
+		// Verify against the endpoint and headers in use, without saving them
+		if err := verifyCredentials(&config.Config{APIToken: token, APIBaseURL: cfg.APIBaseURL, Headers: cfg.Headers}); err != nil {
+			return fmt.Errorf("new token verification failed, saved token left unchanged: %w", err)
 		}
 
+		newCfg.APIToken, newCfg.APIKey, newCfg.APIEmail = token, "", ""
 		if err := newCfg.Save(configPath); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
-		out.WriteSuccess(fmt.Sprintf("Token saved to %s", configPath))
+		if cfg.CredentialSource() == "environment" || cfg.CredentialSource() == "secret file" {
+			fmt.Fprintf(os.Stderr, "Warning: credentials from the %s override the config file; update them too\n", cfg.CredentialSource())
+		}
+		if oldToken != "" {
+			fmt.Fprintf(os.Stderr, "Revoke the old token (%s) in the Cloudflare dashboard under My Profile > API Tokens.\n", output.MaskSecret(oldToken))
+		}
+		if cfg.Profile != "" {
+			out.WriteSuccess(fmt.Sprintf("Token verified and saved to profile %s in %s", cfg.Profile, configPath))
+			return nil
+		}
+		out.WriteSuccess(fmt.Sprintf("Token verified and saved to %s", configPath))
+		return nil
+	},
+}
+
+var authListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the saved credentials and profiles",
+	Long: `List the credentials in the config file: the top-level ones (shown as
+"-") and those of each profile, with their auth method and masked credential.
+The credentials in use are marked with "*".
+
+With --verify, each set of credentials is checked against the API; nothing is
+changed. Failures are reported per profile and make the command exit non-zero.
+
+Examples:
+  cf auth list
+  cf auth list --verify
+  cf --profile work auth save WORK_TOKEN`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configPath := config.ResolvePath(cfgFile)
+		if _, err := config.CheckFile(configPath); err != nil {
+			return fmt.Errorf("config file %s is invalid: %w", configPath, err)
+		}
+		file := config.ReadFile(configPath)
+
+		var entries []authListEntry
+		add := func(name string, creds *config.Config) {
+			credential := output.MaskSecret(creds.APIToken)
+			if creds.APIToken == "" {
+				credential = fmt.Sprintf("%s (%s)", output.MaskSecret(creds.APIKey), creds.APIEmail)
+			}
+			entries = append(entries, authListEntry{
+				Profile:    name,
+				Active:     name == cfg.Profile && strings.HasPrefix(cfg.CredentialSource(), "config file"),
+				AuthMethod: creds.AuthMethod(),
+				Credential: credential,
+			})
+		}
+		if file.HasCredentials() {
+			add("", &config.Config{APIToken: file.APIToken, APIKey: file.APIKey, APIEmail: file.APIEmail})
+		}
+		names := make([]string, 0, len(file.Profiles))
+		for name := range file.Profiles {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			p := file.Profiles[name]
+			add(name, &config.Config{APIToken: p.APIToken, APIKey: p.APIKey, APIEmail: p.APIEmail})
+		}
+
+		if len(entries) == 0 {
+			out.WriteSuccess(fmt.Sprintf("No credentials saved in %s (save some with 'cf auth save <token>')", configPath))
+			return nil
+		}
+
+		failed := 0
+		if authListVerify {
+			stop := startSpinner("Verifying credentials...")
+			for i := range entries {
+				e := &entries[i]
+				creds := &config.Config{APIBaseURL: cfg.APIBaseURL, Headers: cfg.Headers}
+				if e.Profile == "" {
+					creds.APIToken, creds.APIKey, creds.APIEmail = file.APIToken, file.APIKey, file.APIEmail
+				} else {
+					p := file.Profiles[e.Profile]
+					creds.APIToken, creds.APIKey, creds.APIEmail = p.APIToken, p.APIKey, p.APIEmail
+				}
+				err := verifyCredentials(creds)
+				verified := err == nil
+				e.Verified = &verified
+				if err != nil {
+					e.Error = err.Error()
+					failed++
+				}
+			}
+			stop()
+		}
+
+		if outputFormat == "json" {
+			if err := out.WriteJSON(entries); err != nil {
+				return err
+			}
+		} else {
+			out.TeeJSON(entries)
+			headers := []string{"Active", "Profile", "Auth", "Credential"}
+			if authListVerify {
+				headers = append(headers, "Verified", "Error")
+			}
+			var rows [][]string
+			for _, e := range entries {
+				active, profile := "", e.Profile
+				if e.Active {
+					active = "*"
+				}
+				if profile == "" {
+					profile = "-"
+				}
+				row := []string{active, profile, e.AuthMethod, e.Credential}
+				if authListVerify {
+					row = append(row, output.FormatBool(*e.Verified), e.Error)
+				}
+				rows = append(rows, row)
+			}
+			if err := out.WriteTable(headers, rows); err != nil {
+				return err
+			}
+			if src := cfg.CredentialSource(); src == "environment" || src == "secret file" {
+				out.WriteNote(fmt.Sprintf("\nCredentials from the %s are in use and override the config file.", src))
+			}
+		}
+
+		if failed > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d of %d credential(s) failed verification", failed, len(entries))
+		}
 		return nil
 	},
 }
@@ -91,5 +301,9 @@ Example:
 func init() {
 	rootCmd.AddCommand(authCmd)
 	authCmd.AddCommand(authVerifyCmd)
+	authSaveCmd.Flags().BoolVar(&authNoVerify, "no-verify", false, "save the token without verifying it first")
 	authCmd.AddCommand(authSaveCmd)
+	authCmd.AddCommand(authRotateCmd)
+	authListCmd.Flags().BoolVar(&authListVerify, "verify", false, "check each set of credentials against the API")
+	authCmd.AddCommand(authListCmd)
 }
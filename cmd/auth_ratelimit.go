@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+// rateLimitResult is the JSON form of auth ratelimit
+type rateLimitResult struct {
+	Policy        string `json:"policy,omitempty"`
+	Limit         *int   `json:"limit"`
+	Remaining     *int   `json:"remaining"`
+	ResetSeconds  *int   `json:"reset_seconds"`
+	ResetsAt      string `json:"resets_at,omitempty"`
+	WindowSeconds *int   `json:"window_seconds"`
+}
+
+var authRateLimitCmd = &cobra.Command{
+	Use:   "ratelimit",
+	Short: "Show the current API rate-limit budget",
+	Long: `Make a lightweight API call (token verification) and report the rate-limit
+budget from the response headers: the quota, how much of it is left, and when
+it resets. Useful for pacing bulk operations.
+
+To log the budget after every request of another command, use
+--log-format text --verbose.
+
+Examples:
+  cf auth ratelimit
+  cf auth ratelimit -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		stop := startSpinner("Checking rate limit...")
+		err = c.VerifyToken(ctx)
+		stop()
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+		rl, ok := c.RateLimit()
+		if !ok {
+			return fmt.Errorf("the API response carried no rate-limit headers")
+		}
+
+		result := rateLimitResult{Policy: rl.Policy, Limit: rl.Limit, Remaining: rl.Remaining}
+		if rl.Reset > 0 {
+			secs := int(rl.Reset.Seconds())
+			result.ResetSeconds = &secs
+			result.ResetsAt = rl.ObservedAt.Add(rl.Reset).UTC().Format(time.RFC3339)
+		}
+		if rl.Window > 0 {
+			secs := int(rl.Window.Seconds())
+			result.WindowSeconds = &secs
+		}
+
+		if outputFormat == "json" {
+			return out.WriteJSON(result)
+		}
+		out.TeeJSON(result)
+
+		headers := []string{"Key", "Value"}
+		rows := [][]string{
+			{"Policy", orUnknown(rl.Policy)},
+			{"Limit", formatOptionalInt(rl.Limit)},
+			{"Remaining", formatOptionalInt(rl.Remaining)},
+		}
+		if rl.Window > 0 {
+			rows = append(rows, []string{"Window", rl.Window.String()})
+		}
+		if rl.Reset > 0 {
+			rows = append(rows, []string{"Resets In", rl.Reset.String()}, []string{"Resets At", result.ResetsAt})
+		} else {
+			rows = append(rows, []string{"Resets In", "unknown"})
+		}
+		return out.WriteTable(headers, rows)
+	},
+}
+
+func init() {
+	authCmd.AddCommand(authRateLimitCmd)
+}
+
+// formatOptionalInt renders a value the API may not have reported
+func formatOptionalInt(n *int) string {
+	if n == nil {
+		return "unknown"
+	}
+	return strconv.Itoa(*n)
+}
+
+// orUnknown renders an empty string as "unknown"
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
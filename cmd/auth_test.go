@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuthListCredentialSourceNote(t *testing.T) {
+	api := newMockAPI(t)
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("api_token: saved-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, _, err := runCmd(t, api, "auth", "list", "--config", configPath)
+	if err != nil || !strings.Contains(stdout, "Credentials from the environment are in use") {
+		t.Errorf("table output: %v, want the credential source note:\n%s", err, stdout)
+	}
+
+	stdout, _, err = runCmd(t, api, "auth", "list", "--config", configPath, "-o", "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		if !strings.HasPrefix(line, "CF_") {
+			t.Errorf("env output has a line that is not an assignment: %q", line)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+// Error policy shared by bulk commands (import, edit, tag, export-all)
+var (
+	bulkFailFast        bool
+	bulkContinueOnError bool
+	bulkOnlyErrors      bool
+)
+
+// bulkConfirmThreshold is the number of deletions a bulk command may make
+// before it asks for DELETE to be typed, even with --yes (0 disables the check)
+var bulkConfirmThreshold int
+
+// defaultConfirmThreshold is the --confirm-threshold default
+const defaultConfirmThreshold = 10
+
+// Prune guard of commands that delete current records missing from a desired set
+var (
+	pruneAllowEmpty bool
+	pruneMinKeep    float64
+)
+
+// defaultPruneMinKeep is the --min-keep-ratio default
+const defaultPruneMinKeep = 0.5
+
+// addBulkErrorFlags registers --fail-fast, --continue-on-error, and --only-errors
+// on a bulk command. Continuing is the default: every operation is attempted,
+// failures are reported at the end, and the command exits non-zero if any failed.
+func addBulkErrorFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&bulkFailFast, "fail-fast", false, "stop at the first failed operation")
+	cmd.Flags().BoolVar(&bulkContinueOnError, "continue-on-error", true, "attempt every operation and report failures at the end (default)")
+	cmd.Flags().BoolVar(&bulkOnlyErrors, "only-errors", false, "show only failed operations and the summary")
+	cmd.MarkFlagsMutuallyExclusive("fail-fast", "continue-on-error")
+}
+
+// addConfirmThresholdFlag registers --confirm-threshold on a bulk command that deletes records
+func addConfirmThresholdFlag(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&bulkConfirmThreshold, "confirm-threshold", defaultConfirmThreshold, "ask to type DELETE when more records than this would be deleted, even with --yes (0 disables)")
+}
+
+// confirmLargeDelete asks for DELETE to be typed when more than
+// --confirm-threshold records are about to be deleted. --yes does not skip
+// this prompt; dry runs do, since nothing is deleted.
+func confirmLargeDelete(deletes int, dryRun bool) error {
+	if bulkConfirmThreshold <= 0 || deletes <= bulkConfirmThreshold || dryRun {
+		return nil
+	}
+	answer := promptLine(fmt.Sprintf("You're about to delete %d records (more than --confirm-threshold %d). Type DELETE to proceed", deletes, bulkConfirmThreshold), "")
+	if answer != "DELETE" {
+		return fmt.Errorf("aborted: %d deletions exceed --confirm-threshold %d and DELETE was not typed", deletes, bulkConfirmThreshold)
+	}
+	return nil
+}
+
+// addPruneGuardFlags registers --allow-empty and --min-keep-ratio on a command
+// that deletes the current records missing from a desired set
+func addPruneGuardFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&pruneAllowEmpty, "allow-empty", false, "allow a desired set that is empty or below --min-keep-ratio, deleting the records it leaves out")
+	cmd.Flags().Float64Var(&pruneMinKeep, "min-keep-ratio", defaultPruneMinKeep, "refuse to prune when the desired set keeps less than this fraction of the current records (0 disables)")
+}
+
+// checkPruneGuard refuses a prune that keeps none of the current records, or
+// fewer than --min-keep-ratio of them, unless --allow-empty is set. An empty
+// desired set is far more likely a truncated or mistaken file than a request
+// to delete the whole zone.
+func checkPruneGuard(current, kept int) error {
+	if pruneAllowEmpty || current == 0 {
+		return nil
+	}
+	if kept == 0 {
+		return fmt.Errorf("refusing to delete all %d records: the desired set is empty (use --allow-empty if that is intended)", current)
+	}
+	if pruneMinKeep > 0 && float64(kept) < pruneMinKeep*float64(current) {
+		return fmt.Errorf("refusing to delete %d of %d records: the desired set keeps fewer than --min-keep-ratio %g of them (use --allow-empty if that is intended)", current-kept, current, pruneMinKeep)
+	}
+	return nil
+}
+
+// writeBulkResults writes the per-operation result table of a bulk command.
+// With --only-errors, rows with an empty Error column are left out, and
+// nothing is written in table mode if no operation failed.
+func writeBulkResults(headers []string, rows [][]string) error {
+	if bulkOnlyErrors {
+		col := slices.Index(headers, "Error")
+		rows = slices.DeleteFunc(rows, func(row []string) bool { return col < 0 || row[col] == "" })
+		if len(rows) == 0 && outputFormat != "json" {
+			return nil
+		}
+	}
+	return out.WriteTable(headers, rows)
+}
+
+// stopAfterFailure reports whether a bulk command should skip its remaining
+// operations, given how many have failed so far
+func stopAfterFailure(failed int) bool {
+	return failed > 0 && (bulkFailFast || !bulkContinueOnError)
+}
+
+// skippedAfterFailure is the error shown for operations skipped by --fail-fast
+const skippedAfterFailure = "skipped after an earlier failure (--fail-fast)"
+
+// onlyFailed drops the successful results when --only-errors is given, for
+// bulk commands that write their results as JSON directly
+func onlyFailed[T any](results []T, failed func(T) bool) []T {
+	if !bulkOnlyErrors {
+		return results
+	}
+	kept := []T{}
+	for _, r := range results {
+		if failed(r) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+func TestBulkSummaryOutput(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+	dir := t.TempDir()
+	idFile := func(records ...cloudflare.DNSRecord) string {
+		var ids []string
+		for _, r := range records {
+			ids = append(ids, r.ID)
+		}
+		path := filepath.Join(dir, "ids")
+		if err := os.WriteFile(path, []byte(strings.Join(ids, "\n")), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	// --only-errors without failures leaves only the summary
+	ids := idFile(api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "a", Content: "192.0.2.1"}))
+	stdout, _, err := runCmd(t, api, "dns", "delete", "example.com", "--record-id-file", ids, "--yes", "--only-errors")
+	if err != nil || strings.TrimSpace(stdout) != "1 of 1 record(s) deleted, 0 failed" {
+		t.Errorf("table output: %v, want only the summary:\n%s", err, stdout)
+	}
+
+	ids = idFile(api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "b", Content: "192.0.2.2"}))
+	tee := filepath.Join(dir, "tee.json")
+	stdout, _, err = runCmd(t, api, "dns", "delete", "example.com", "--record-id-file", ids, "--yes", "-o", "env", "--tee", tee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		if !strings.HasPrefix(line, "CF_") {
+			t.Errorf("env output has a line that is not an assignment: %q", line)
+		}
+	}
+	if data, _ := os.ReadFile(tee); !strings.Contains(string(data), "1 of 1 record(s) deleted, 0 failed") {
+		t.Errorf("tee does not have the summary:\n%s", data)
+	}
+
+	ids = idFile(api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "c", Content: "192.0.2.3"}))
+	stdout, _, err = runCmd(t, api, "dns", "delete", "example.com", "--record-id-file", ids, "--yes", "--template", "{{.Status}}")
+	if err != nil || strings.Contains(stdout, "deleted,") {
+		t.Errorf("template output: %v, want no summary:\n%s", err, stdout)
+	}
+}
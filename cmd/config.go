@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 
+	"github.com/coollabsio/cloudflare-cli/internal/client"
 	"github.com/coollabsio/cloudflare-cli/internal/config"
+	"github.com/coollabsio/cloudflare-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -12,48 +19,115 @@ var configCmd = &cobra.Command{
 	Short: "Configuration commands",
 }
 
+// configKeys are the keys accepted by config set and config get
+var configKeys = []string{"output_format", "default_proxied", "default_ttl", "min_ttl", "max_retries", "api_base_url", "api_token", "api_key", "api_email"}
+
+var configNoVerify bool
+
 var configSetCmd = &cobra.Command{
 	Use:   "set <key> <value>",
 	Short: "Set a config value",
 	Long: `Set a configuration value.
 
 Available keys:
-  output_format  - Default output format (table, json)
+  output_format    - Default output format (table, json)
+  default_proxied  - Proxy new A/AAAA/CNAME records by default on dns create (true, false)
+  default_ttl      - TTL used by dns create when --ttl is not given (60-86400, or auto)
+  min_ttl          - Lowest TTL dns create/update accept without --force; auto is
+                     always allowed (60-86400, or off)
+  max_retries      - How often a failed API request is retried (1-10, default 3)
+  api_base_url     - API endpoint, e.g. a regional endpoint (default ` + client.DefaultBaseURL + `)
+  api_token        - API token (verified before saving unless --no-verify)
+  api_key          - Global API key (used with api_email)
+  api_email        - Account email for the global API key
+
+Credentials are masked when echoed. With --profile, api_token, api_key, and
+api_email are stored under that profile.
 
 Examples:
   cf config set output_format json
-  cf config set output_format table`,
+  cf config set default_proxied true
+  cf config set default_ttl 3600
+  cf config set min_ttl 300
+  cf config set max_retries 5
+  cf config set api_token YOUR_API_TOKEN`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := args[0]
 		value := args[1]
 
 		// Load existing config
-		configPath := cfgFile
-		if configPath == "" {
-			configPath = config.DefaultConfigPath()
-		}
+		configPath := config.ResolvePath(cfgFile)
 
-		existingCfg, _ := config.Load(configPath)
-		if existingCfg == nil {
-			existingCfg = &config.Config{}
-		}
+		// Start from the file alone, so nothing from the environment is saved
+		existingCfg := config.LoadFile(configPath, cfg.Profile)
 
+		display := value
 		switch key {
 		case "output_format":
 			if value != "table" && value != "json" {
 				return fmt.Errorf("invalid output_format: %s (must be 'table' or 'json')", value)
 			}
 			existingCfg.OutputFormat = value
+		case "default_proxied":
+			if value != "true" && value != "false" {
+				return fmt.Errorf("invalid default_proxied: %s (must be 'true' or 'false')", value)
+			}
+			existingCfg.DefaultProxied = value == "true"
+		case "default_ttl":
+			ttl, err := output.ParseTTL(value)
+			if err != nil {
+				return fmt.Errorf("invalid default_ttl: %w", err)
+			}
+			existingCfg.DefaultTTL = ttl
+			display = output.FormatTTL(ttl)
+		case "min_ttl":
+			if value == "off" || value == "0" {
+				existingCfg.MinTTL = 0
+				display = "off"
+				break
+			}
+			ttl, err := output.ParseTTL(value)
+			if err != nil || ttl == 1 {
+				return fmt.Errorf("invalid min_ttl: %s (must be a TTL from 60 to 86400, or 'off')", value)
+			}
+			existingCfg.MinTTL = ttl
+		case "max_retries":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 10 {
+				return fmt.Errorf("invalid max_retries: %s (must be a number from 1 to 10; use --no-retry to disable retries)", value)
+			}
+			existingCfg.MaxRetries = n
+		case "api_base_url":
+			if err := client.CheckBaseURL(value); err != nil {
+				return err
+			}
+			existingCfg.APIBaseURL = value
+		case "api_token":
+			if configNoVerify {
+				fmt.Fprintln(os.Stderr, "Warning: saving token without verification (--no-verify); it has not been checked against the API")
+			} else if err := verifyCredentials(&config.Config{APIToken: value, APIBaseURL: cfg.APIBaseURL, Headers: cfg.Headers}); err != nil {
+				return fmt.Errorf("token verification failed: %w", err)
+			}
+			existingCfg.APIToken = value
+			display = output.MaskSecret(value)
+		case "api_key":
+			existingCfg.APIKey = value
+			display = output.MaskSecret(value)
+		case "api_email":
+			if !strings.Contains(value, "@") {
+				return fmt.Errorf("invalid api_email: %s", value)
+			}
+			existingCfg.APIEmail = value
 		default:
-			return fmt.Errorf("unknown config key: %s", key)
+			return unknownConfigKey(key)
 		}
 
 		if err := existingCfg.Save(configPath); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
-		out.WriteSuccess(fmt.Sprintf("Set %s = %s", key, value))
+		out.WriteSuccess(fmt.Sprintf("Set %s = %s", key, display))
 		return nil
 	},
 }
@@ -64,7 +138,15 @@ var configGetCmd = &cobra.Command{
 	Long: `Get a configuration value.
 
 Available keys:
-  output_format  - Default output format
+  output_format    - Default output format
+  default_proxied  - Whether dns create proxies A/AAAA/CNAME records by default
+  default_ttl      - TTL used by dns create when --ttl is not given
+  min_ttl          - Lowest TTL dns create/update accept without --force
+  max_retries      - How often a failed API request is retried
+  api_base_url     - API endpoint in use
+  api_token        - API token (masked)
+  api_key          - Global API key (masked)
+  api_email        - Account email for the global API key
 
 Examples:
   cf config get output_format`,
@@ -79,17 +161,109 @@ Examples:
 				value = "table"
 			}
 			fmt.Println(value)
+		case "default_proxied":
+			fmt.Println(output.FormatBool(cfg.DefaultProxied))
+		case "default_ttl":
+			fmt.Println(output.FormatTTL(max(cfg.DefaultTTL, 1)))
+		case "min_ttl":
+			fmt.Println(configMinTTL())
+		case "max_retries":
+			fmt.Println(configMaxRetries())
+		case "api_base_url":
+			fmt.Println(configBaseURL())
+		case "api_token":
+			fmt.Println(output.MaskSecret(cfg.APIToken))
+		case "api_key":
+			fmt.Println(output.MaskSecret(cfg.APIKey))
+		case "api_email":
+			fmt.Println(cfg.APIEmail)
 		default:
-			return fmt.Errorf("unknown config key: %s", key)
+			return unknownConfigKey(key)
 		}
 
 		return nil
 	},
 }
 
+// unknownConfigKey returns the error for a key config set/get doesn't know
+func unknownConfigKey(key string) error {
+	return fmt.Errorf("unknown config key: %s (valid keys: %s)", key, strings.Join(configKeys, ", "))
+}
+
+// configMinTTL describes the min_ttl policy, or "off" if none is set
+func configMinTTL() string {
+	if cfg.MinTTL == 0 {
+		return "off"
+	}
+	return strconv.Itoa(cfg.MinTTL)
+}
+
+// configFileCheck describes the config file for config validate and doctor.
+// A missing file is fine (the defaults are used); one that doesn't parse fails.
+func configFileCheck() (bool, string) {
+	configPath := config.ResolvePath(cfgFile)
+	exists, err := config.CheckFile(configPath)
+	switch {
+	case err != nil:
+		return false, fmt.Sprintf("%s: %v", configPath, err)
+	case !exists:
+		return true, fmt.Sprintf("%s (not found, using defaults)", configPath)
+	}
+	return true, configPath
+}
+
+// credentialsCheck describes the active credentials and where they come from
+// for config validate and doctor, or which credential is missing
+func credentialsCheck() (bool, string) {
+	if err := cfg.CheckCredentials(); err != nil {
+		return false, err.Error()
+	}
+	return true, fmt.Sprintf("%s (from %s)", cfg.AuthMethod(), cfg.CredentialSource())
+}
+
+// minTTLCheck describes the min_ttl policy for config validate and doctor. It
+// fails if default_ttl itself is below the policy.
+func minTTLCheck() (bool, string) {
+	if cfg.MinTTL == 0 {
+		return true, "off (no TTL policy)"
+	}
+	if cfg.DefaultTTL > 1 && cfg.DefaultTTL < cfg.MinTTL {
+		return false, fmt.Sprintf("%d, but default_ttl %d is below it", cfg.MinTTL, cfg.DefaultTTL)
+	}
+	return true, fmt.Sprintf("%d (lower TTLs are rejected unless --force; auto is allowed)", cfg.MinTTL)
+}
+
+// configMaxRetries returns the effective max_retries value
+func configMaxRetries() int {
+	if cfg.MaxRetries == 0 {
+		return client.DefaultMaxRetries
+	}
+	return cfg.MaxRetries
+}
+
+// configBaseURL returns the effective API base URL
+func configBaseURL() string {
+	if cfg.APIBaseURL == "" {
+		return client.DefaultBaseURL
+	}
+	return cfg.APIBaseURL
+}
+
+// verifyCredentials checks credentials against the API, as auth save does
+func verifyCredentials(creds *config.Config) error {
+	c, err := client.New(creds)
+	if err != nil {
+		return err
+	}
+	return c.VerifyToken(context.Background())
+}
+
 var configListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all config values",
+	Long: `List all config values, including credentials from the config file or environment.
+
+Credentials are masked to their last 4 characters unless --show-secrets is given.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		outputFormat := cfg.OutputFormat
 		if outputFormat == "" {
@@ -99,14 +273,204 @@ var configListCmd = &cobra.Command{
 		headers := []string{"Key", "Value"}
 		rows := [][]string{
 			{"output_format", outputFormat},
+			{"default_proxied", output.FormatBool(cfg.DefaultProxied)},
+			{"default_ttl", output.FormatTTL(max(cfg.DefaultTTL, 1))},
+			{"min_ttl", configMinTTL()},
+			{"max_retries", strconv.Itoa(configMaxRetries())},
+			{"api_base_url", configBaseURL()},
+		}
+		if cfg.APIToken != "" {
+			rows = append(rows, []string{"api_token", displaySecret(cfg.APIToken)})
+		}
+		if cfg.APIKey != "" {
+			rows = append(rows, []string{"api_key", displaySecret(cfg.APIKey)})
+		}
+		if cfg.APIEmail != "" {
+			rows = append(rows, []string{"api_email", cfg.APIEmail})
 		}
 		return out.WriteTable(headers, rows)
 	},
 }
 
+// displaySecret masks a credential for display unless --show-secrets was given
+func displaySecret(secret string) string {
+	if configShowSecrets {
+		return secret
+	}
+	return output.MaskSecret(secret)
+}
+
+var (
+	configValidateVerify bool
+	configShowSecrets    bool
+)
+
+// configCheck is a single result of config validate
+type configCheck struct {
+	Check  string `json:"check"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail"`
+}
+
+// configValidation is the result of config validate
+type configValidation struct {
+	Valid  bool          `json:"valid"`
+	Checks []configCheck `json:"checks"`
+}
+
+// configMigration is the result of config migrate
+type configMigration struct {
+	Path    string   `json:"path"`
+	Version int      `json:"version"`
+	Changes []string `json:"changes"`
+	Backup  string   `json:"backup"`
+	DryRun  bool     `json:"dry_run"`
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the config file and credentials",
+	Long: `Validate the configuration: check that the config file parses, show which
+credentials are active and where they come from, and check the output format.
+
+Use --verify to also verify the credentials against the Cloudflare API.
+
+Examples:
+  cf config validate
+  cf config validate --verify`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var checks []configCheck
+
+		// Config file
+		ok, detail := configFileCheck()
+		checks = append(checks, configCheck{"config_file", ok, detail})
+
+		// Credentials
+		ok, detail = credentialsCheck()
+		checks = append(checks, configCheck{"credentials", ok, detail})
+
+		// Output format
+		switch cfg.OutputFormat {
+		case "":
+			checks = append(checks, configCheck{"output_format", true, "table (default)"})
+		case "table", "json":
+			checks = append(checks, configCheck{"output_format", true, cfg.OutputFormat})
+		default:
+			checks = append(checks, configCheck{"output_format", false, fmt.Sprintf("invalid value %q (must be 'table' or 'json')", cfg.OutputFormat)})
+		}
+
+		// TTL policy
+		ok, detail = minTTLCheck()
+		checks = append(checks, configCheck{"min_ttl", ok, detail})
+
+		// API endpoint
+		if err := client.CheckBaseURL(configBaseURL()); err != nil {
+			checks = append(checks, configCheck{"api_base_url", false, err.Error()})
+		} else {
+			checks = append(checks, configCheck{"api_base_url", true, configBaseURL()})
+		}
+
+		// Optionally verify credentials against the API
+		if configValidateVerify && cfg.HasCredentials() {
+			if err := verifyCredentials(cfg); err != nil {
+				checks = append(checks, configCheck{"verify", false, err.Error()})
+			} else {
+				checks = append(checks, configCheck{"verify", true, "credentials are valid"})
+			}
+		}
+
+		failed := 0
+		for _, c := range checks {
+			if !c.OK {
+				failed++
+			}
+		}
+
+		result := configValidation{Valid: failed == 0, Checks: checks}
+		if outputFormat == "json" {
+			if err := out.WriteJSON(result); err != nil {
+				return err
+			}
+		} else {
+			out.TeeJSON(result)
+			headers := []string{"Check", "Status", "Detail"}
+			var rows [][]string
+			for _, c := range checks {
+				status := "ok"
+				if !c.OK {
+					status = "FAIL"
+				}
+				rows = append(rows, []string{c.Check, status, c.Detail})
+			}
+			if err := out.WriteTable(headers, rows); err != nil {
+				return err
+			}
+		}
+
+		if failed > 0 {
+			return errors.New("config validation failed")
+		}
+		return nil
+	},
+}
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade the config file to the current format",
+	Long: `Upgrade the config file to the current schema version and print what changed.
+The original file is kept next to it as <file>.<timestamp>.bak.
+
+Version 2 moves top-level credentials into the "default" profile, which is
+used when no --profile is given. Keys cf does not know are removed (they stay
+in the backup). Running it again on an upgraded file changes nothing. With
+--dry-run the changes are only shown.
+
+Examples:
+  cf config migrate
+  cf config migrate --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		result, err := config.Migrate(cfgFile, dryRun)
+		if err != nil {
+			return err
+		}
+
+		changes := result.Changes
+		if changes == nil {
+			changes = []string{}
+		}
+		summary := configMigration{Path: result.Path, Version: result.Version, Changes: changes, Backup: result.Backup, DryRun: dryRun}
+		if outputFormat == "json" {
+			return out.WriteJSON(summary)
+		}
+		out.TeeJSON(summary)
+
+		if len(result.Changes) == 0 {
+			out.WriteSuccess(fmt.Sprintf("%s is already at version %d", result.Path, result.Version))
+			return nil
+		}
+		for _, change := range result.Changes {
+			fmt.Printf("  - %s\n", change)
+		}
+		if dryRun {
+			out.WriteSuccess(fmt.Sprintf("(dry-run) Would migrate %s to version %d", result.Path, result.Version))
+			return nil
+		}
+		out.WriteSuccess(fmt.Sprintf("Migrated %s to version %d (backup: %s)", result.Path, result.Version, result.Backup))
+		return nil
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
+	configSetCmd.Flags().BoolVar(&configNoVerify, "no-verify", false, "with api_token, save the token without verifying it first")
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configGetCmd)
+	configListCmd.Flags().BoolVar(&configShowSecrets, "show-secrets", false, "show credentials in full instead of masked")
 	configCmd.AddCommand(configListCmd)
+
+	configValidateCmd.Flags().BoolVar(&configValidateVerify, "verify", false, "also verify credentials against the Cloudflare API")
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configMigrateCmd)
 }
@@ -1,24 +1,73 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/coollabsio/cloudflare-cli/internal/client"
 	"github.com/coollabsio/cloudflare-cli/internal/output"
+	"github.com/coollabsio/cloudflare-cli/internal/resolver"
 	"github.com/spf13/cobra"
 )
 
 var (
-	dnsType     string
-	dnsName     string
-	dnsContent  string
-	dnsTTL      int
-	dnsProxied  string
-	dnsPriority uint16
-	dnsComment  string
-	dnsSearch   string
+	dnsType      string
+	dnsName      string
+	dnsApex      bool
+	dnsContent   string
+	dnsContents  []string
+	dnsTTL       int
+	dnsProxied   string
+	dnsPriority  uint16
+	dnsComment   string
+	dnsSearch    string
+	dnsContains  string
+	dnsNamesFile string
+	dnsYes       bool
+	dnsTrace     bool
+	dnsDiff      bool
+	dnsUnique    bool
+	dnsStrict    bool
+	dnsForce     bool
+
+	dnsInheritProxied bool
+
+	dnsContentFile string
+
+	listResolveCNAME bool
+	listGroupBy      string
+	listWide         bool
+	listRedact       bool
+	listTTLMin       int
+	listTTLMax       int
+	listIncludeAuto  bool
+	listExpandTXT    bool
+	listShort        bool
+	listFQDN         bool
+	listDuplicates   bool
+	listPage         int
+	listPageSize     int
+)
+
+const (
+	// cnameMaxDepth caps how many CNAME hops --resolve-cname follows
+	cnameMaxDepth = 10
+	// cnameLookupTimeout bounds the resolution of a single CNAME chain
+	cnameLookupTimeout = 5 * time.Second
+	// cnameLookupWorkers is the number of chains resolved in parallel
+	cnameLookupWorkers = 16
 )
 
 var dnsCmd = &cobra.Command{
@@ -36,57 +85,198 @@ Examples:
   cf dns list example.com --type A
   cf dns list example.com --name www
   cf dns list example.com --search "production"
-  cf dns list 023e105f4ecef8ad9ca31a8372d0c353`,
+  cf dns list example.com --name-contains staging
+  cf dns list example.com --type A --proxied
+  cf dns list example.com --type A --proxied --count
+  cf dns list example.com --name-contains staging --output-ids
+  cf dns list example.com --type CNAME --resolve-cname
+  cf dns list example.com --group-by type
+  cf dns list example.com --wide
+  cf dns list example.com --redact-origins
+  cf dns list example.com --ttl-max 300
+  cf dns list example.com --ttl-min 3600 --include-auto
+  cf dns list example.com --type TXT --expand-txt
+  cf dns list example.com --short
+  cf dns list example.com --duplicates
+  cf dns list example.com --page 2 --page-size 50
+  cf dns list 023e105f4ecef8ad9ca31a8372d0c353
+
+With --resolve-cname, the target of each CNAME record is followed through live
+DNS and the final name and addresses are shown in a "Resolves To" column
+("loop" if the chain loops or is too long, "unresolved" if a lookup fails).
+
+With --wide, a Proxiable column shows whether Cloudflare can proxy each record.
+
+With --redact-origins, the content of proxied A and AAAA records (the origin
+addresses hidden behind Cloudflare) is shown as *** so the output can be
+shared safely.
+
+--ttl-min and --ttl-max keep records whose TTL (in seconds) falls in the
+range. Records with an automatic TTL are left out of a TTL range unless
+--include-auto is given.
+
+With --expand-txt, TXT records recognized as SPF, DKIM, or DMARC are also
+shown split into their mechanisms or tags, each with a short explanation.
+With -o json, only these expansions are printed.
+
+Names are shown fully qualified by default (--fqdn). With --short, the zone
+suffix is stripped from displayed names (www instead of www.example.com, @ for
+the apex); JSON output always keeps the full names.
+
+With --duplicates, only records that look like accidental duplicates are
+shown: several records with the same name and type (flagged when their
+content is identical), and CNAMEs that share their name with other records.
+Each row names the issue of its group. Filters narrow the records checked, so
+use it without --type to see CNAME conflicts.
+
+--page shows one page of the (filtered) records, --page-size records long
+(default 50), with a "Showing 51–100 of 430" footer. --count still counts
+every matching record.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if listGroupBy != "" && listGroupBy != "type" {
+			return fmt.Errorf("invalid --group-by: %s (must be 'type')", listGroupBy)
+		}
+		if listShort && listFQDN {
+			return fmt.Errorf("--short and --fqdn cannot be used together")
+		}
+		if listTTLMin < 0 || listTTLMax < 0 {
+			return fmt.Errorf("--ttl-min and --ttl-max must not be negative")
+		}
+		if listTTLMax != 0 && listTTLMin > listTTLMax {
+			return fmt.Errorf("--ttl-min (%d) is greater than --ttl-max (%d)", listTTLMin, listTTLMax)
+		}
+		if listPage < 0 {
+			return fmt.Errorf("--page must not be negative")
+		}
+		if listPageSize < 1 {
+			return fmt.Errorf("--page-size must be at least 1")
+		}
+		if cmd.Flags().Changed("page-size") && listPage == 0 {
+			listPage = 1
+		}
+
 		c, err := client.New(cfg)
 		if err != nil {
 			return err
 		}
 
 		ctx := context.Background()
-		zoneID, err := resolveZone(c, ctx, args[0])
+		zone, err := resolveZoneDetails(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		records, err := c.ListDNSRecords(ctx, zone.ID, dnsType, recordName(zone.Name))
 		if err != nil {
 			return err
 		}
 
-		records, err := c.ListDNSRecords(ctx, zoneID, dnsType, dnsName)
+		records, err = filterDNSRecords(records, zone.Name)
 		if err != nil {
 			return err
 		}
+		records = filterTTLRange(records, listTTLMin, listTTLMax, listIncludeAuto)
 
-		// Filter by search term (case-insensitive search in name, content, comment)
-		if dnsSearch != "" {
-			search := strings.ToLower(dnsSearch)
-			var filtered []client.DNSRecord
+		if listCount {
+			return writeCount(len(records))
+		}
+		total := len(records)
+		if listPage > 0 {
+			records = pageRecords(records, listPage, listPageSize)
+		}
+		if listOutputIDs {
+			var ids []string
 			for _, r := range records {
-				if strings.Contains(strings.ToLower(r.Name), search) ||
-					strings.Contains(strings.ToLower(r.Content), search) ||
-					strings.Contains(strings.ToLower(r.Comment), search) {
-					filtered = append(filtered, r)
-				}
+				ids = append(ids, r.ID)
 			}
-			records = filtered
+			writeIDs(ids)
+			return nil
 		}
 
 		if len(records) == 0 {
+			if listPage > 1 && total > 0 {
+				out.WriteSuccess(fmt.Sprintf("No DNS records on page %d (%d record(s) in total)", listPage, total))
+				return nil
+			}
 			out.WriteSuccess("No DNS records found")
 			return nil
 		}
+		if listRedact {
+			redactOrigins(records)
+		}
 
-		return writeDNSRecordTable(records)
+		if err := writeDNSRecordList(ctx, records, zone.Name); err != nil {
+			return err
+		}
+		if listPage > 0 {
+			first := (listPage-1)*listPageSize + 1
+			out.WriteNote(fmt.Sprintf("\nShowing %d–%d of %d", first, first+len(records)-1, total))
+		}
+		return nil
 	},
 }
 
+// writeDNSRecordList writes the records of dns list in the layout selected by
+// its flags
+func writeDNSRecordList(ctx context.Context, records []client.DNSRecord, zoneName string) error {
+	if listDuplicates {
+		return writeDuplicates(records, zoneName)
+	}
+	if listGroupBy != "" {
+		return writeGroupedDNSRecords(records, zoneName)
+	}
+	if listExpandTXT {
+		expansions := expandTXTRecords(records)
+		if outputFormat != "json" {
+			out.TeeJSON(expansions)
+			if err := writeDNSRecordTable(records, zoneName); err != nil {
+				return err
+			}
+		}
+		return writeTXTExpansions(expansions)
+	}
+	if listResolveCNAME {
+		return writeResolvedDNSRecordTable(records, resolveCNAMETargets(ctx, records), zoneName)
+	}
+	return writeDNSRecordTable(records, zoneName)
+}
+
+// pageRecords returns page (counted from 1) of records, size records long
+func pageRecords(records []client.DNSRecord, page, size int) []client.DNSRecord {
+	start := (page - 1) * size
+	if start >= len(records) {
+		return nil
+	}
+	return records[start:min(start+size, len(records))]
+}
+
 var dnsGetCmd = &cobra.Command{
-	Use:   "get <zone> <record-id>",
+	Use:   "get <zone> [record-id]",
 	Short: "Get DNS record details",
 	Long: `Get details for a specific DNS record.
 
-Example:
-  cf dns get example.com 372e67954025e0ba6aaa6d586b9e0b59`,
-	Args: cobra.ExactArgs(2),
+Use --trace to print the raw HTTP request and response to stderr.
+
+With --record-id-file, every record listed in the file (one ID per line;
+blank lines and # comments are ignored) is fetched and shown in one table,
+with per-record errors.
+
+Examples:
+  cf dns get example.com 372e67954025e0ba6aaa6d586b9e0b59
+  cf dns get example.com 372e67954025e0ba6aaa6d586b9e0b59 --trace
+  cf dns get example.com --record-id-file ids.txt
+  eval "$(cf dns get example.com 372e67954025e0ba6aaa6d586b9e0b59 -o env)"`,
+	Args: recordIDArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		var ids []string
+		if dnsRecordIDFile != "" {
+			var err error
+			if ids, err = readRecordIDFile(dnsRecordIDFile); err != nil {
+				return err
+			}
+		}
+
 		c, err := client.New(cfg)
 		if err != nil {
 			return err
@@ -97,8 +287,19 @@ Example:
 		if err != nil {
 			return err
 		}
+		if ids != nil {
+			// Per-record failures are reported in the output, not as a usage problem
+			cmd.SilenceUsage = true
+			return getRecordsByID(ctx, c, zoneID, ids)
+		}
 
+		if dnsTrace {
+			c.EnableTrace()
+		}
 		record, err := c.GetDNSRecord(ctx, zoneID, args[1])
+		if dnsTrace {
+			writeTraces(c.Traces())
+		}
 		if err != nil {
 			return err
 		}
@@ -106,7 +307,12 @@ Example:
 		if outputFormat == "json" {
 			return out.WriteJSON(record)
 		}
+		out.TeeJSON(record)
+		if out.IsTemplate() {
+			return out.WriteTemplate(record)
+		}
 
+		out.SetEnvPrefix("CF_RECORD")
 		headers := []string{"ID", "Type", "Name", "Content", "TTL", "Proxied", "Comment"}
 		rows := [][]string{{
 			record.ID,
@@ -129,15 +335,101 @@ var dnsCreateCmd = &cobra.Command{
 Examples:
   cf dns create example.com --name www --type A --content 192.0.2.1
   cf dns create example.com --name www --type CNAME --content example.com --proxied
-  cf dns create example.com --name mail --type MX --content mail.example.com --priority 10`,
+  cf dns create example.com --name mail --type MX --content mail.example.com --priority 10
+  cf dns create example.com --name default._domainkey --type TXT --content-file dkim.txt
+  cf dns create example.com --name _443._tcp --type TLSA --data usage=3,selector=1,matching_type=1,certificate=abc123...
+  cf dns create example.com --name _sip._tcp --type SRV --priority 10 --weight 5 --port 5060 --content sip.example.com
+  cf dns create example.com --name _http._tcp --type URI --priority 10 --weight 1 --content https://www.example.com/
+
+Types with structured data (LOC, NAPTR, SRV, SSHFP, TLSA, HTTPS, SVCB, CAA,
+URI) take --data key=value pairs (repeatable or comma-separated) or
+--data-json instead of --content (use --data-json for values containing
+commas). The required fields of each type are checked before the request.
+SRV and URI records can instead be given with --priority, --weight, --port
+(SRV only), and --content as the target.
+
+Names are relative to the zone: --name www becomes www.example.com, and
+--name @, --apex, or the bare zone name is the zone apex. Names that already
+end in the zone name are used as they are.
+
+Reverse records: in a reverse zone, --name is the address part relative to the
+zone and --content is the hostname it resolves to:
+  cf dns create 2.0.192.in-addr.arpa --type PTR --name 1 --content host.example.com
+
+Delegating a subdomain: NS records cannot be proxied, and --content may be
+repeated to create one NS record per nameserver in a single invocation:
+  cf dns create example.com --name dev --type NS --content ns1.other-dns.com --content ns2.other-dns.com
+
+A CNAME cannot share its name with other records (at the zone apex, with A,
+AAAA, or other CNAME records), so conflicting creates are rejected before they
+reach the API. A CNAME at the zone apex is allowed: Cloudflare flattens it,
+answering with the target's addresses, and cf prints a note saying so. dns
+list marks such records as "CNAME (flattened)".
+
+If default_proxied is enabled in the config, A, AAAA, and CNAME records are
+proxied unless --proxied=false is given. Other types are never proxied by default.
+Without --ttl, the default_ttl config value is used (auto if unset).
+
+With --unique, nothing is created when a record with the same name, type, and
+content already exists. This is treated as success unless --strict is given:
+  cf dns create example.com --name www --type A --content 192.0.2.1 --unique
+
+With --inherit-proxied, a record added next to existing records of the same
+name and type gets their proxy status unless --proxied is given, so adding an
+address doesn't silently leave it unproxied:
+  cf dns create example.com --name www --type A --content 192.0.2.2 --inherit-proxied`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if dnsType == "" || dnsName == "" || dnsContent == "" {
-			return fmt.Errorf("--type, --name, and --content are required")
+		if dnsContentFile != "" {
+			if len(dnsContents) > 0 {
+				return fmt.Errorf("--content and --content-file cannot be used together")
+			}
+			content, err := readContentFile(dnsContentFile)
+			if err != nil {
+				return err
+			}
+			dnsContents = []string{content}
+		}
+		if dnsType == "" || (dnsName == "" && !dnsApex) || (len(dnsContents) == 0 && !hasRecordData()) {
+			return fmt.Errorf("--type, --name, and --content (or --content-file or --data) are required")
+		}
+		data, err := parseRecordData(dnsType)
+		if err != nil {
+			return err
+		}
+		if data != nil && len(dnsContents) > 0 {
+			return fmt.Errorf("--content and --data cannot be used together")
+		}
+		if data == nil {
+			if data, err = recordDataFromFlags(cmd, dnsType); err != nil {
+				return err
+			}
+		} else if cmd.Flags().Changed("weight") || cmd.Flags().Changed("port") {
+			return fmt.Errorf("--weight and --port cannot be used with --data")
+		}
+		if data != nil {
+			if dnsUnique {
+				return fmt.Errorf("--unique cannot be used with structured record data (--data, --weight, --port)")
+			}
+			// Structured records have no content; create exactly one
+			dnsContents = []string{""}
+		}
+		if len(dnsContents) > 1 && !strings.EqualFold(dnsType, "NS") {
+			return fmt.Errorf("multiple --content values are only supported for NS records")
+		}
+		if dnsStrict && !dnsUnique {
+			return fmt.Errorf("--strict can only be used with --unique")
 		}
 
-		// Parse proxied flag
-		proxied := false
+		if !cmd.Flags().Changed("ttl") && cfg.DefaultTTL != 0 {
+			dnsTTL = cfg.DefaultTTL
+		}
+		if err := checkMinTTL(dnsTTL); err != nil {
+			return err
+		}
+
+		// Parse proxied flag, falling back to the default_proxied config for proxiable types
+		proxied := cfg.DefaultProxied && isProxiableType(dnsType)
 		if dnsProxied != "" {
 			if dnsProxied != "true" && dnsProxied != "false" {
 				return fmt.Errorf("--proxied must be 'true' or 'false'")
@@ -145,39 +437,139 @@ Examples:
 			proxied = dnsProxied == "true"
 		}
 
+		for _, content := range dnsContents {
+			if data != nil {
+				break
+			}
+			if err := validateRecordContent(dnsType, content, proxied); err != nil {
+				return err
+			}
+		}
+
 		c, err := client.New(cfg)
 		if err != nil {
 			return err
 		}
 
 		ctx := context.Background()
-		zoneID, err := resolveZone(c, ctx, args[0])
+		zone, err := resolveZoneDetails(c, ctx, args[0])
 		if err != nil {
 			return err
 		}
+		zoneID := zone.ID
 
-		params := client.CreateDNSRecordParams{
-			Type:    dnsType,
-			Name:    dnsName,
-			Content: dnsContent,
-			TTL:     dnsTTL,
-			Proxied: proxied,
-			Comment: dnsComment,
+		// An explicit --proxied always wins over the inherited status
+		if dnsInheritProxied && !cmd.Flags().Changed("proxied") {
+			proxied, err = inheritProxied(ctx, c, zone, proxied)
+			if err != nil {
+				return err
+			}
+			for _, content := range dnsContents {
+				if data != nil {
+					break
+				}
+				if err := validateRecordContent(dnsType, content, proxied); err != nil {
+					return err
+				}
+			}
 		}
-		if dnsPriority > 0 {
-			params.Priority = &dnsPriority
+
+		contents := dnsContents
+		var existing []client.DNSRecord
+		if dnsUnique {
+			contents, existing, err = skipIdenticalRecords(ctx, c, zone, dnsContents)
+			if err != nil {
+				return err
+			}
+			if len(existing) > 0 && dnsStrict {
+				return fmt.Errorf("an identical %s record for %s already exists: %s", existing[0].Type, existing[0].Name, existing[0].ID)
+			}
+			for _, r := range existing {
+				fmt.Fprintf(os.Stderr, "Skipping %s %s %s: identical record already exists (%s)\n", r.Type, r.Name, r.Content, r.ID)
+			}
+			if len(contents) == 0 {
+				if len(existing) == 1 {
+					if outputFormat == "json" {
+						return out.WriteJSON(existing[0])
+					}
+					out.TeeJSON(existing[0])
+				} else {
+					if outputFormat == "json" {
+						return out.WriteJSON(existing)
+					}
+					out.TeeJSON(existing)
+				}
+				out.WriteSuccess("Identical DNS record already exists; nothing created")
+				return nil
+			}
 		}
 
-		record, err := c.CreateDNSRecord(ctx, zoneID, params)
-		if err != nil {
+		if err := checkCNAMEConflict(ctx, c, zone); err != nil {
 			return err
 		}
+		if strings.EqualFold(dnsType, "CNAME") && strings.EqualFold(recordName(zone.Name), zone.Name) {
+			fmt.Fprintf(os.Stderr, "Note: a CNAME at the zone apex is flattened by Cloudflare: resolvers get the A/AAAA addresses of %s instead of a CNAME, so it can coexist with MX, TXT, and other apex records.\n", strings.Join(contents, ", "))
+		}
+
+		var created []client.DNSRecord
+		for _, content := range contents {
+			params := client.CreateDNSRecordParams{
+				Type:    dnsType,
+				Name:    recordName(zone.Name),
+				Content: content,
+				TTL:     dnsTTL,
+				Proxied: proxied,
+				Comment: dnsComment,
+				Data:    data,
+			}
+			if dnsPriority > 0 {
+				params.Priority = &dnsPriority
+			}
+
+			record, err := c.CreateDNSRecord(ctx, zoneID, params)
+			if err != nil {
+				if len(created) > 0 {
+					return fmt.Errorf("%w (%d record(s) were already created)", err, len(created))
+				}
+				return err
+			}
+			created = append(created, *record)
+		}
 
+		if len(created) > 1 {
+			var result interface{} = created
+			if c.DryRun() {
+				result = dryRunRecords{DryRun: true, Records: created}
+			}
+			if outputFormat == "json" {
+				return out.WriteJSON(result)
+			}
+			out.TeeJSON(result)
+			if out.IsTemplate() {
+				return out.WriteTemplate(created)
+			}
+			if c.DryRun() {
+				out.WriteSuccess(fmt.Sprintf("(dry-run) Would create %d DNS records", len(created)))
+			} else {
+				out.WriteSuccess(fmt.Sprintf("Created %d DNS records", len(created)))
+			}
+			return writeDNSRecordTable(created, "")
+		}
+
+		record := &created[0]
 		if outputFormat == "json" {
-			return out.WriteJSON(record)
+			return out.WriteJSON(recordJSON(c, record))
+		}
+		out.TeeJSON(recordJSON(c, record))
+		if out.IsTemplate() {
+			return out.WriteTemplate(record)
 		}
 
-		out.WriteSuccess(fmt.Sprintf("Created DNS record: %s", record.ID))
+		if c.DryRun() {
+			out.WriteSuccess("(dry-run) Would create DNS record")
+		} else {
+			out.WriteSuccess(fmt.Sprintf("Created DNS record: %s", record.ID))
+		}
 		headers := []string{"ID", "Type", "Name", "Content", "TTL", "Proxied", "Comment"}
 		rows := [][]string{{
 			record.ID,
@@ -203,19 +595,36 @@ Examples:
   cf dns update example.com 372e67954025e0ba6aaa6d586b9e0b59 --content 192.0.2.2
   cf dns update example.com 372e67954025e0ba6aaa6d586b9e0b59 --name www2
   cf dns update example.com 372e67954025e0ba6aaa6d586b9e0b59 --proxied
-  cf dns update example.com 372e67954025e0ba6aaa6d586b9e0b59 --proxied=false`,
+  cf dns update example.com 372e67954025e0ba6aaa6d586b9e0b59 --proxied=false
+
+A new --name is relative to the zone, as for dns create ("@" is the apex).
+
+Turning off proxying on an A, AAAA, or CNAME record exposes the origin
+address, so it asks for confirmation first. Use --yes to skip the prompt.
+
+With --diff, the fields that would change are printed (to stderr) with their
+current and new values, and the update asks for confirmation unless --yes is
+given. Nothing is sent if no field would change:
+  cf dns update example.com 372e67954025e0ba6aaa6d586b9e0b59 --content 192.0.2.2 --ttl 300 --diff`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("ttl") {
+			if err := checkMinTTL(dnsTTL); err != nil {
+				return err
+			}
+		}
+
 		c, err := client.New(cfg)
 		if err != nil {
 			return err
 		}
 
 		ctx := context.Background()
-		zoneID, err := resolveZone(c, ctx, args[0])
+		zone, err := resolveZoneDetails(c, ctx, args[0])
 		if err != nil {
 			return err
 		}
+		zoneID := zone.ID
 
 		// Fetch existing record first
 		existing, err := c.GetDNSRecord(ctx, zoneID, args[1])
@@ -228,18 +637,28 @@ Examples:
 			Type:    existing.Type,
 			Name:    existing.Name,
 			Content: existing.Content,
+			Tags:    existing.Tags,
 		}
 
 		// Override only the fields that were explicitly set
 		if cmd.Flags().Changed("type") {
 			params.Type = dnsType
 		}
-		if cmd.Flags().Changed("name") {
-			params.Name = dnsName
+		if cmd.Flags().Changed("name") || dnsApex {
+			params.Name = recordName(zone.Name)
 		}
 		if cmd.Flags().Changed("content") {
 			params.Content = dnsContent
 		}
+		if dnsContentFile != "" {
+			if cmd.Flags().Changed("content") {
+				return fmt.Errorf("--content and --content-file cannot be used together")
+			}
+			params.Content, err = readContentFile(dnsContentFile)
+			if err != nil {
+				return err
+			}
+		}
 		if cmd.Flags().Changed("ttl") {
 			params.TTL = &dnsTTL
 		}
@@ -256,6 +675,36 @@ Examples:
 		if cmd.Flags().Changed("comment") {
 			params.Comment = &dnsComment
 		}
+		if hasRecordData() {
+			params.Data, err = parseRecordData(params.Type)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Guard against accidentally exposing the origin by disabling the proxy
+		if existing.Proxied && params.Proxied != nil && !*params.Proxied && isProxiableType(params.Type) {
+			fmt.Fprintf(os.Stderr, "Warning: disabling the proxy on %s %s will expose its origin (%s) in public DNS.\n",
+				params.Type, params.Name, params.Content)
+			if !dnsYes && !c.DryRun() && !confirm("Disable proxying for this record?") {
+				return fmt.Errorf("aborted: proxy left enabled (use --yes to skip confirmation)")
+			}
+		}
+
+		if dnsDiff {
+			changes := recordUpdateDiff(existing, params)
+			if len(changes) == 0 {
+				out.WriteSuccess("No fields would change; nothing updated")
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "Changes to %s %s (%s):\n", existing.Type, existing.Name, existing.ID)
+			for _, ch := range changes {
+				fmt.Fprintf(os.Stderr, "  %-8s %s -> %s\n", ch.field, ch.before, ch.after)
+			}
+			if !dnsYes && !c.DryRun() && !confirm("Apply these changes?") {
+				return fmt.Errorf("aborted: record left unchanged (use --yes to skip confirmation)")
+			}
+		}
 
 		record, err := c.UpdateDNSRecord(ctx, zoneID, args[1], params)
 		if err != nil {
@@ -263,10 +712,18 @@ Examples:
 		}
 
 		if outputFormat == "json" {
-			return out.WriteJSON(record)
+			return out.WriteJSON(recordJSON(c, record))
+		}
+		out.TeeJSON(recordJSON(c, record))
+		if out.IsTemplate() {
+			return out.WriteTemplate(record)
 		}
 
-		out.WriteSuccess(fmt.Sprintf("Updated DNS record: %s", record.ID))
+		if c.DryRun() {
+			out.WriteSuccess(fmt.Sprintf("(dry-run) Would update DNS record: %s", record.ID))
+		} else {
+			out.WriteSuccess(fmt.Sprintf("Updated DNS record: %s", record.ID))
+		}
 		headers := []string{"ID", "Type", "Name", "Content", "TTL", "Proxied", "Comment"}
 		rows := [][]string{{
 			record.ID,
@@ -282,14 +739,29 @@ Examples:
 }
 
 var dnsDeleteCmd = &cobra.Command{
-	Use:   "delete <zone> <record-id>",
+	Use:   "delete <zone> [record-id]",
 	Short: "Delete a DNS record",
 	Long: `Delete a DNS record.
 
-Example:
-  cf dns delete example.com 372e67954025e0ba6aaa6d586b9e0b59`,
-	Args: cobra.ExactArgs(2),
+With --record-id-file, every record listed in the file (one ID per line;
+blank lines and # comments are ignored) is deleted, e.g. IDs collected with
+dns find -o json. This asks for confirmation unless --yes is given, and
+deleting more than --confirm-threshold records (default 10) also asks you to
+type DELETE. Each record's result is reported; use --dry-run to preview.
+
+Examples:
+  cf dns delete example.com 372e67954025e0ba6aaa6d586b9e0b59
+  cf dns delete example.com --record-id-file ids.txt --dry-run`,
+	Args: recordIDArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		var ids []string
+		if dnsRecordIDFile != "" {
+			var err error
+			if ids, err = readRecordIDFile(dnsRecordIDFile); err != nil {
+				return err
+			}
+		}
+
 		c, err := client.New(cfg)
 		if err != nil {
 			return err
@@ -300,11 +772,19 @@ Example:
 		if err != nil {
 			return err
 		}
+		if ids != nil {
+			cmd.SilenceUsage = true
+			return deleteRecordsByID(ctx, c, zoneID, ids)
+		}
 
 		if err := c.DeleteDNSRecord(ctx, zoneID, args[1]); err != nil {
 			return err
 		}
 
+		if c.DryRun() {
+			out.WriteSuccess(fmt.Sprintf("(dry-run) Would delete DNS record: %s", args[1]))
+			return nil
+		}
 		out.WriteSuccess(fmt.Sprintf("Deleted DNS record: %s", args[1]))
 		return nil
 	},
@@ -314,14 +794,20 @@ var dnsFindCmd = &cobra.Command{
 	Use:   "find <zone>",
 	Short: "Find DNS records by name and type",
 	Long: `Find DNS records by name and/or type. Useful for getting record IDs.
+Names are relative to the zone; --name @ (or --apex) finds the zone apex.
+
+With -o json, the matching records are printed as an array of the same
+objects as dns get (an empty array if nothing matches).
 
 Examples:
   cf dns find example.com --name www --type A
-  cf dns find example.com --name mail --type MX`,
+  cf dns find example.com --name mail --type MX
+  cf dns find example.com --apex --type A
+  cf dns find example.com --name www --type A -o json | jq -r '.[0].ID'`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if dnsName == "" && dnsType == "" {
-			return fmt.Errorf("at least one of --name or --type is required")
+		if dnsName == "" && !dnsApex && dnsType == "" {
+			return fmt.Errorf("at least one of --name, --apex, or --type is required")
 		}
 
 		c, err := client.New(cfg)
@@ -330,22 +816,31 @@ Examples:
 		}
 
 		ctx := context.Background()
-		zoneID, err := resolveZone(c, ctx, args[0])
+		zone, err := resolveZoneDetails(c, ctx, args[0])
 		if err != nil {
 			return err
 		}
 
-		records, err := c.FindDNSRecords(ctx, zoneID, dnsName, dnsType)
+		records, err := c.FindDNSRecords(ctx, zone.ID, recordName(zone.Name), dnsType)
 		if err != nil {
 			return err
 		}
 
+		// Emit typed records (same shape as dns get) so IDs can be scripted
+		if records == nil {
+			records = []client.DNSRecord{}
+		}
+		if outputFormat == "json" {
+			return out.WriteJSON(records)
+		}
+		out.TeeJSON(records)
+
 		if len(records) == 0 {
 			out.WriteSuccess("No matching DNS records found")
 			return nil
 		}
 
-		return writeDNSRecordTable(records)
+		return writeDNSRecordTable(records, "")
 	},
 }
 
@@ -353,41 +848,647 @@ func init() {
 	rootCmd.AddCommand(dnsCmd)
 
 	// List command
-	dnsListCmd.Flags().StringVarP(&dnsType, "type", "t", "", "filter by record type (A, AAAA, CNAME, TXT, MX, etc.)")
-	dnsListCmd.Flags().StringVarP(&dnsName, "name", "n", "", "filter by record name")
-	dnsListCmd.Flags().StringVarP(&dnsSearch, "search", "s", "", "search in name, content, and comment (case-insensitive)")
+	addDNSFilterFlags(dnsListCmd)
+	dnsListCmd.Flags().BoolVar(&listCount, "count", false, "print only the number of matching records")
+	dnsListCmd.Flags().BoolVar(&listOutputIDs, "output-ids", false, "print only record IDs, one per line")
+	dnsListCmd.Flags().StringVar(&listGroupBy, "group-by", "", "group records into one table per field value (type)")
+	dnsListCmd.Flags().BoolVar(&listRedact, "redact-origins", false, "replace the content of proxied A/AAAA records with ***")
+	dnsListCmd.Flags().IntVar(&listTTLMin, "ttl-min", 0, "only records with a TTL of at least this many seconds")
+	dnsListCmd.Flags().IntVar(&listTTLMax, "ttl-max", 0, "only records with a TTL of at most this many seconds")
+	dnsListCmd.Flags().BoolVar(&listIncludeAuto, "include-auto", false, "with --ttl-min/--ttl-max, also keep records with an automatic TTL")
+	dnsListCmd.Flags().BoolVar(&listExpandTXT, "expand-txt", false, "also show SPF, DKIM, and DMARC records split into annotated terms")
+	dnsListCmd.Flags().BoolVar(&listShort, "short", false, "show names relative to the zone (@ for the apex)")
+	dnsListCmd.Flags().BoolVar(&listFQDN, "fqdn", false, "show fully qualified names (default)")
+	dnsListCmd.Flags().IntVar(&listPage, "page", 0, "show only this page of records (counted from 1)")
+	dnsListCmd.Flags().IntVar(&listPageSize, "page-size", 50, "records per page with --page")
+	dnsListCmd.Flags().BoolVar(&listDuplicates, "duplicates", false, "show only duplicate records (same name and type) and CNAME conflicts")
+	dnsListCmd.Flags().BoolVar(&listWide, "wide", false, "show extra columns (Proxiable)")
+	dnsListCmd.Flags().BoolVar(&listResolveCNAME, "resolve-cname", false, "follow each CNAME target through live DNS and show where it ends up")
 	dnsCmd.AddCommand(dnsListCmd)
 
 	// Get command
+	dnsGetCmd.Flags().BoolVar(&dnsTrace, "trace", false, "print the raw API request and response to stderr")
+	dnsGetCmd.Flags().StringVar(&dnsRecordIDFile, "record-id-file", "", "get every record whose ID is listed in this file (one per line, # comments)")
+	addBulkErrorFlags(dnsGetCmd)
 	dnsCmd.AddCommand(dnsGetCmd)
 
 	// Create command
 	dnsCreateCmd.Flags().StringVarP(&dnsType, "type", "t", "", "record type (required)")
 	dnsCreateCmd.Flags().StringVarP(&dnsName, "name", "n", "", "record name (required)")
-	dnsCreateCmd.Flags().StringVarP(&dnsContent, "content", "c", "", "record content (required)")
-	dnsCreateCmd.Flags().IntVar(&dnsTTL, "ttl", 1, "TTL in seconds (1 = auto)")
+	addApexFlag(dnsCreateCmd)
+	dnsCreateCmd.Flags().StringArrayVarP(&dnsContents, "content", "c", nil, "record content (required; repeat for multiple NS records)")
+	dnsCreateCmd.Flags().StringVar(&dnsContentFile, "content-file", "", "read the record content from a file ('-' for stdin)")
+	dnsTTL = 1
+	dnsCreateCmd.Flags().Var((*ttlValue)(&dnsTTL), "ttl", "TTL in seconds, or 'auto'")
 	dnsCreateCmd.Flags().StringVar(&dnsProxied, "proxied", "", "proxy through Cloudflare (true|false)")
 	dnsCreateCmd.Flags().Lookup("proxied").NoOptDefVal = "true"
-	dnsCreateCmd.Flags().Uint16Var(&dnsPriority, "priority", 0, "record priority (for MX, SRV)")
+	dnsCreateCmd.Flags().Uint16Var(&dnsPriority, "priority", 0, "record priority (for MX, SRV, URI)")
+	dnsCreateCmd.Flags().Uint16Var(&dnsWeight, "weight", 0, "record weight (for SRV, URI); --content becomes the target")
+	dnsCreateCmd.Flags().Uint16Var(&dnsPort, "port", 0, "record port (for SRV)")
 	dnsCreateCmd.Flags().StringVar(&dnsComment, "comment", "", "comment for the record")
+	dnsCreateCmd.Flags().StringSliceVar(&dnsData, "data", nil, "structured record data as key=value pairs (LOC, NAPTR, SRV, SSHFP, TLSA, HTTPS, SVCB, CAA, URI)")
+	dnsCreateCmd.Flags().StringVar(&dnsDataJSON, "data-json", "", "structured record data as a JSON object")
+	dnsCreateCmd.Flags().BoolVar(&dnsUnique, "unique", false, "skip creating if an identical record (same name, type, and content) exists")
+	dnsCreateCmd.Flags().BoolVar(&dnsStrict, "strict", false, "with --unique, fail instead of succeeding when an identical record exists")
+	dnsCreateCmd.Flags().BoolVar(&dnsInheritProxied, "inherit-proxied", false, "copy the proxy status of existing records with the same name and type")
+	dnsCreateCmd.Flags().BoolVar(&dnsForce, "force", false, "allow a TTL below the min_ttl policy of the config")
 	dnsCmd.AddCommand(dnsCreateCmd)
 
 	// Update command
 	dnsUpdateCmd.Flags().StringVarP(&dnsType, "type", "t", "", "new record type")
 	dnsUpdateCmd.Flags().StringVarP(&dnsName, "name", "n", "", "new record name")
+	addApexFlag(dnsUpdateCmd)
 	dnsUpdateCmd.Flags().StringVarP(&dnsContent, "content", "c", "", "new record content")
-	dnsUpdateCmd.Flags().IntVar(&dnsTTL, "ttl", 1, "TTL in seconds (1 = auto)")
+	dnsUpdateCmd.Flags().StringVar(&dnsContentFile, "content-file", "", "read the new record content from a file ('-' for stdin)")
+	dnsUpdateCmd.Flags().Var((*ttlValue)(&dnsTTL), "ttl", "TTL in seconds, or 'auto'")
 	dnsUpdateCmd.Flags().StringVar(&dnsProxied, "proxied", "", "set proxy status (true|false)")
 	dnsUpdateCmd.Flags().Lookup("proxied").NoOptDefVal = "true"
 	dnsUpdateCmd.Flags().Uint16Var(&dnsPriority, "priority", 0, "record priority (for MX, SRV)")
 	dnsUpdateCmd.Flags().StringVar(&dnsComment, "comment", "", "comment for the record (use empty string to clear)")
+	dnsUpdateCmd.Flags().StringSliceVar(&dnsData, "data", nil, "replace the structured record data with these key=value pairs")
+	dnsUpdateCmd.Flags().StringVar(&dnsDataJSON, "data-json", "", "replace the structured record data with this JSON object")
+	dnsUpdateCmd.Flags().BoolVarP(&dnsYes, "yes", "y", false, "skip confirmation prompts")
+	dnsUpdateCmd.Flags().BoolVar(&dnsDiff, "diff", false, "show the fields that would change and ask before updating")
+	dnsUpdateCmd.Flags().BoolVar(&dnsForce, "force", false, "allow a TTL below the min_ttl policy of the config")
 	dnsCmd.AddCommand(dnsUpdateCmd)
 
 	// Delete command
+	dnsDeleteCmd.Flags().StringVar(&dnsRecordIDFile, "record-id-file", "", "delete every record whose ID is listed in this file (one per line, # comments)")
+	dnsDeleteCmd.Flags().BoolVarP(&dnsYes, "yes", "y", false, "with --record-id-file, skip the confirmation prompt")
+	addBulkErrorFlags(dnsDeleteCmd)
+	addConfirmThresholdFlag(dnsDeleteCmd)
 	dnsCmd.AddCommand(dnsDeleteCmd)
 
 	// Find command
 	dnsFindCmd.Flags().StringVarP(&dnsType, "type", "t", "", "record type to find")
-	dnsFindCmd.Flags().StringVarP(&dnsName, "name", "n", "", "record name to find")
+	dnsFindCmd.Flags().StringVarP(&dnsName, "name", "n", "", "record name to find (relative to the zone, @ for the apex)")
+	addApexFlag(dnsFindCmd)
 	dnsCmd.AddCommand(dnsFindCmd)
 }
+
+// ttlValue is a flag value for TTLs that accepts "auto" as well as seconds
+type ttlValue int
+
+func (t *ttlValue) String() string { return output.FormatTTL(int(*t)) }
+
+func (t *ttlValue) Set(s string) error {
+	ttl, err := output.ParseTTL(s)
+	if err != nil {
+		return err
+	}
+	*t = ttlValue(ttl)
+	return nil
+}
+
+func (t *ttlValue) Type() string { return "ttl" }
+
+// readContentFile reads record content from a file, or stdin for "-",
+// without the trailing newline
+func readContentFile(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read content file: %w", err)
+	}
+	content := strings.TrimRight(string(data), "\r\n")
+	if content == "" {
+		return "", fmt.Errorf("content file %s is empty", path)
+	}
+	return content, nil
+}
+
+// skipIdenticalRecords looks up records with the same name and type and splits
+// contents into those still to be created and the records that already match
+func skipIdenticalRecords(ctx context.Context, c *client.Client, zone *client.Zone, contents []string) ([]string, []client.DNSRecord, error) {
+	records, err := c.FindDNSRecords(ctx, zone.ID, recordName(zone.Name), dnsType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var remaining []string
+	var existing []client.DNSRecord
+	for _, content := range contents {
+		idx := slices.IndexFunc(records, func(r client.DNSRecord) bool {
+			return sameContent(dnsType, r.Content, content)
+		})
+		if idx >= 0 {
+			existing = append(existing, records[idx])
+			continue
+		}
+		remaining = append(remaining, content)
+	}
+	return remaining, existing, nil
+}
+
+// checkCNAMEConflict fails if the record being created would share its name with
+// a CNAME (or, for a CNAME, with any other record). At the zone apex Cloudflare
+// flattens CNAMEs, so there only A, AAAA, and CNAME records conflict.
+func checkCNAMEConflict(ctx context.Context, c *client.Client, zone *client.Zone) error {
+	name := recordName(zone.Name)
+	records, err := c.FindDNSRecords(ctx, zone.ID, name, "")
+	if err != nil {
+		return err
+	}
+
+	isCNAME := strings.EqualFold(dnsType, "CNAME")
+	apex := strings.EqualFold(name, zone.Name)
+	for _, r := range records {
+		if !isCNAME && !strings.EqualFold(r.Type, "CNAME") {
+			continue
+		}
+		if apex && !(isProxiableType(r.Type) && isProxiableType(dnsType)) {
+			continue
+		}
+		if isCNAME && strings.EqualFold(r.Type, "CNAME") {
+			return fmt.Errorf("a CNAME record already exists at %s (%s -> %s); a name can only have one CNAME", name, r.ID, r.Content)
+		}
+		if isCNAME {
+			return fmt.Errorf("cannot create a CNAME at %s: a %s record already exists there (%s); a CNAME cannot coexist with other records", name, r.Type, r.ID)
+		}
+		return fmt.Errorf("cannot create a %s record at %s: a CNAME already exists there (%s -> %s); a CNAME cannot coexist with other records", strings.ToUpper(dnsType), name, r.ID, r.Content)
+	}
+	return nil
+}
+
+// isFlattenedApexCNAME reports whether r is a CNAME at the apex of zoneName,
+// which Cloudflare always flattens
+func isFlattenedApexCNAME(r client.DNSRecord, zoneName string) bool {
+	return strings.EqualFold(r.Type, "CNAME") && strings.EqualFold(strings.TrimSuffix(r.Name, "."), zoneName)
+}
+
+// recordTypeLabel returns the type shown for r in record tables, marking
+// flattened apex CNAMEs. zoneName may be empty when it isn't known.
+func recordTypeLabel(r client.DNSRecord, zoneName string) string {
+	if zoneName != "" && isFlattenedApexCNAME(r, zoneName) {
+		return r.Type + " (flattened)"
+	}
+	return r.Type
+}
+
+// qualifyName expands a record name relative to the zone into a fully
+// qualified name ("@" is the zone apex)
+func qualifyName(name, zoneName string) string {
+	name = strings.TrimSuffix(name, ".")
+	if name == "@" || strings.EqualFold(name, zoneName) {
+		return zoneName
+	}
+	if strings.HasSuffix(strings.ToLower(name), "."+strings.ToLower(zoneName)) {
+		return name
+	}
+	return name + "." + zoneName
+}
+
+// recordName returns the record name given with --name (or --apex) qualified
+// with the zone, or "" if neither was given
+func recordName(zoneName string) string {
+	if dnsApex {
+		return zoneName
+	}
+	if dnsName == "" {
+		return ""
+	}
+	return qualifyName(dnsName, zoneName)
+}
+
+// addApexFlag registers --apex as an alternative to --name @ on a command
+// that takes --name
+func addApexFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&dnsApex, "apex", false, "use the zone apex as the record name (same as --name @)")
+	cmd.MarkFlagsMutuallyExclusive("name", "apex")
+}
+
+// checkMinTTL enforces the min_ttl policy of the config on a TTL given to dns
+// create or update, unless --force is given. The automatic TTL is always allowed.
+func checkMinTTL(ttl int) error {
+	if cfg.MinTTL == 0 || ttl <= 1 || ttl >= cfg.MinTTL || dnsForce {
+		return nil
+	}
+	return fmt.Errorf("policy violation: TTL %d is below the min_ttl of %d set in the config (use auto, a TTL of at least %d, or --force)", ttl, cfg.MinTTL, cfg.MinTTL)
+}
+
+// sameContent compares record contents, ignoring case and a trailing dot for
+// hostname types, and quoting and chunking for TXT
+func sameContent(recordType, a, b string) bool {
+	return comparableContent(recordType, a) == comparableContent(recordType, b)
+}
+
+// comparableContent normalizes record content for comparison: hostnames are
+// lowercased without a trailing dot, and TXT content is unquoted with its
+// strings joined, so "v=DKIM1; p=AB" "CD" equals v=DKIM1; p=ABCD
+func comparableContent(recordType, content string) string {
+	switch strings.ToUpper(recordType) {
+	case "CNAME", "MX", "NS", "PTR":
+		return strings.ToLower(strings.TrimSuffix(content, "."))
+	case "TXT":
+		return unquoteTXT(content)
+	}
+	return content
+}
+
+// validateRecordContent checks type-specific rules before a record is sent to the API
+func validateRecordContent(recordType, content string, proxied bool) error {
+	switch strings.ToUpper(recordType) {
+	case "NS":
+		if proxied {
+			return fmt.Errorf("NS records cannot be proxied")
+		}
+		if !isHostname(content) {
+			return fmt.Errorf("invalid NS content %q: expected a single nameserver hostname (e.g. ns1.example.net)", content)
+		}
+	case "PTR":
+		if proxied {
+			return fmt.Errorf("PTR records cannot be proxied")
+		}
+		if net.ParseIP(content) != nil {
+			return fmt.Errorf("invalid PTR content %q: content is the hostname the address points to; put the address part in --name (e.g. --name 1 --content host.example.com)", content)
+		}
+		if !isHostname(content) {
+			return fmt.Errorf("invalid PTR content %q: expected a hostname (e.g. host.example.com)", content)
+		}
+	}
+	return nil
+}
+
+// isHostname reports whether s looks like a DNS hostname (an optional trailing dot is allowed)
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, ch := range label {
+			if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '-' || ch == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isProxiableType reports whether records of the given type can be proxied by Cloudflare
+func isProxiableType(recordType string) bool {
+	switch strings.ToUpper(recordType) {
+	case "A", "AAAA", "CNAME":
+		return true
+	}
+	return false
+}
+
+// addDNSFilterFlags registers the record filter flags shared by list and export
+func addDNSFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&dnsType, "type", "t", "", "filter by record type (A, AAAA, CNAME, TXT, MX, etc.)")
+	cmd.Flags().StringVarP(&dnsName, "name", "n", "", "filter by record name (relative to the zone, @ for the apex)")
+	addApexFlag(cmd)
+	cmd.Flags().StringVar(&dnsContains, "name-contains", "", "filter by records whose name contains this string (case-insensitive)")
+	cmd.Flags().StringVarP(&dnsSearch, "search", "s", "", "search in name, content, and comment (case-insensitive)")
+	cmd.Flags().StringVar(&dnsNamesFile, "names-from-file", "", "only records whose name is listed in this file (one per line, # comments)")
+	cmd.Flags().StringVar(&dnsProxied, "proxied", "", "filter by proxy status (true|false)")
+	cmd.Flags().Lookup("proxied").NoOptDefVal = "true"
+}
+
+// filterDNSRecords applies the client-side filters (--name-contains, --search, --proxied,
+// --names-from-file). The --type and --name filters are applied by the API in ListDNSRecords.
+func filterDNSRecords(records []client.DNSRecord, zoneName string) ([]client.DNSRecord, error) {
+	if dnsProxied != "" && dnsProxied != "true" && dnsProxied != "false" {
+		return nil, fmt.Errorf("--proxied must be 'true' or 'false'")
+	}
+
+	var names map[string]bool
+	if dnsNamesFile != "" {
+		var err error
+		if names, err = readNamesFile(dnsNamesFile, zoneName); err != nil {
+			return nil, err
+		}
+	}
+
+	contains := strings.ToLower(dnsContains)
+	search := strings.ToLower(dnsSearch)
+
+	var filtered []client.DNSRecord
+	for _, r := range records {
+		if contains != "" && !strings.Contains(strings.ToLower(r.Name), contains) {
+			continue
+		}
+		// Case-insensitive search in name, content, comment
+		if search != "" &&
+			!strings.Contains(strings.ToLower(r.Name), search) &&
+			!strings.Contains(strings.ToLower(r.Content), search) &&
+			!strings.Contains(strings.ToLower(r.Comment), search) {
+			continue
+		}
+		if dnsProxied != "" && r.Proxied != (dnsProxied == "true") {
+			continue
+		}
+		if names != nil && !names[strings.ToLower(strings.TrimSuffix(r.Name, "."))] {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered, nil
+}
+
+// filterTTLRange keeps records whose TTL is within [minTTL, maxTTL]; 0 leaves a bound
+// open. Automatic TTLs (1) are only kept if includeAuto is set.
+func filterTTLRange(records []client.DNSRecord, minTTL, maxTTL int, includeAuto bool) []client.DNSRecord {
+	if minTTL == 0 && maxTTL == 0 {
+		return records
+	}
+
+	var filtered []client.DNSRecord
+	for _, r := range records {
+		if r.TTL == 1 {
+			if includeAuto {
+				filtered = append(filtered, r)
+			}
+			continue
+		}
+		if (minTTL != 0 && r.TTL < minTTL) || (maxTTL != 0 && r.TTL > maxTTL) {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
+
+// redactOrigins hides the origin address of proxied A and AAAA records
+func redactOrigins(records []client.DNSRecord) {
+	for i, r := range records {
+		if r.Proxied && (strings.EqualFold(r.Type, "A") || strings.EqualFold(r.Type, "AAAA")) {
+			records[i].Content = "***"
+		}
+	}
+}
+
+// readNamesFile reads record names, one per line, into a set of lowercase fully
+// qualified names. Blank lines and lines starting with # are ignored.
+func readNamesFile(path, zoneName string) (map[string]bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read names file: %w", err)
+	}
+
+	names := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names[strings.ToLower(qualifyName(line, zoneName))] = true
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("names file %s does not list any names", path)
+	}
+	return names, nil
+}
+
+// recordGroups is the JSON form of dns list --group-by: records keyed by type
+type recordGroups map[string][]client.DNSRecord
+
+// writeGroupedDNSRecords writes records grouped by type, one table per type
+// with a count, or a JSON object keyed by type
+func writeGroupedDNSRecords(records []client.DNSRecord, zoneName string) error {
+	groups := make(recordGroups)
+	for _, r := range records {
+		groups[r.Type] = append(groups[r.Type], r)
+	}
+	types := make([]string, 0, len(groups))
+	for t := range groups {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	if outputFormat == "json" {
+		return out.WriteJSON(groups)
+	}
+	out.TeeJSON(groups)
+	if out.IsTemplate() {
+		return out.WriteTemplate(records)
+	}
+
+	for i, t := range types {
+		heading := fmt.Sprintf("%s (%d)", t, len(groups[t]))
+		if i > 0 {
+			heading = "\n" + heading
+		}
+		out.WriteNote(heading)
+		if err := writeDNSRecordTable(groups[t], zoneName); err != nil {
+			return err
+		}
+	}
+	out.WriteNote(fmt.Sprintf("\n%d record(s) in %d type(s)", len(records), len(types)))
+	return nil
+}
+
+// resolveCNAMETargets follows the chain of every CNAME record in parallel and
+// returns a description of where each one ends up, keyed by record ID
+func resolveCNAMETargets(ctx context.Context, records []client.DNSRecord) map[string]string {
+	stop := startSpinner("Resolving CNAME chains...")
+	defer stop()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		targets = make(map[string]string)
+		jobs    = make(chan client.DNSRecord)
+	)
+	for w := 0; w < cnameLookupWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range jobs {
+				lookupCtx, cancel := context.WithTimeout(ctx, cnameLookupTimeout)
+				result, err := resolver.FollowCNAME(lookupCtx, r.Content, cnameMaxDepth)
+				cancel()
+
+				desc := "unresolved"
+				switch {
+				case result.Loop:
+					desc = "loop"
+				case err == nil:
+					desc = fmt.Sprintf("%s (%s)", result.Target, strings.Join(result.Addrs, ", "))
+				}
+
+				mu.Lock()
+				targets[r.ID] = desc
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, r := range records {
+		if strings.EqualFold(r.Type, "CNAME") {
+			jobs <- r
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return targets
+}
+
+// inheritProxied returns the proxy status of the existing records with the
+// name and type being created, or fallback if there are none. Existing records
+// that disagree are an error, since there is nothing sensible to inherit.
+func inheritProxied(ctx context.Context, c *client.Client, zone *client.Zone, fallback bool) (bool, error) {
+	existing, err := c.FindDNSRecords(ctx, zone.ID, recordName(zone.Name), strings.ToUpper(dnsType))
+	if err != nil {
+		return false, err
+	}
+	if len(existing) == 0 {
+		return fallback, nil
+	}
+	proxied := existing[0].Proxied
+	for _, r := range existing[1:] {
+		if r.Proxied != proxied {
+			return false, fmt.Errorf("existing %s records for %s disagree on proxy status; pass --proxied to choose", existing[0].Type, existing[0].Name)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Inheriting proxied=%t from %d existing %s record(s) for %s\n", proxied, len(existing), existing[0].Type, existing[0].Name)
+	return proxied, nil
+}
+
+// displayName returns the name to show for a record: relative to zoneName
+// with --short, fully qualified otherwise
+func displayName(name, zoneName string) string {
+	if !listShort || zoneName == "" {
+		return name
+	}
+	return relativeName(name, zoneName)
+}
+
+// relativeName returns name relative to the zone ("@" for the apex); names
+// outside the zone are returned as they are. It is the inverse of qualifyName.
+func relativeName(name, zoneName string) string {
+	if strings.EqualFold(name, zoneName) {
+		return "@"
+	}
+	if len(name) > len(zoneName)+1 && strings.EqualFold(name[len(name)-len(zoneName)-1:], "."+zoneName) {
+		return name[:len(name)-len(zoneName)-1]
+	}
+	return name
+}
+
+// fieldChange is one field of a record that an update would change
+type fieldChange struct {
+	field, before, after string
+}
+
+// recordUpdateDiff lists the fields of existing that params would change, with
+// their current and new values
+func recordUpdateDiff(existing *client.DNSRecord, params client.UpdateDNSRecordParams) []fieldChange {
+	var changes []fieldChange
+	add := func(field, before, after string) {
+		if before != after {
+			changes = append(changes, fieldChange{field, before, after})
+		}
+	}
+	quote := func(s string) string { return strconv.Quote(s) }
+
+	add("Type", existing.Type, params.Type)
+	add("Name", existing.Name, params.Name)
+	if params.Data == nil {
+		add("Content", quote(existing.Content), quote(params.Content))
+	}
+	if params.TTL != nil {
+		add("TTL", output.FormatTTL(existing.TTL), output.FormatTTL(*params.TTL))
+	}
+	if params.Proxied != nil {
+		add("Proxied", output.FormatBool(existing.Proxied), output.FormatBool(*params.Proxied))
+	}
+	if params.Priority != nil {
+		before := "none"
+		if existing.Priority != nil {
+			before = strconv.Itoa(int(*existing.Priority))
+		}
+		add("Priority", before, strconv.Itoa(int(*params.Priority)))
+	}
+	if params.Comment != nil {
+		add("Comment", quote(existing.Comment), quote(*params.Comment))
+	}
+	if params.Data != nil {
+		before, _ := json.Marshal(existing.Data)
+		after, _ := json.Marshal(params.Data)
+		add("Data", string(before), string(after))
+	}
+	return changes
+}
+
+// writeResolvedDNSRecordTable writes DNS records with an extra column holding the resolved CNAME target
+func writeResolvedDNSRecordTable(records []client.DNSRecord, targets map[string]string, zoneName string) error {
+	headers := []string{"ID", "Type", "Name", "Content", "TTL", "Proxied", "Resolves To"}
+	var rows [][]string
+	for _, r := range records {
+		rows = append(rows, []string{
+			r.ID,
+			recordTypeLabel(r, zoneName),
+			displayName(r.Name, zoneName),
+			r.Content,
+			output.FormatTTL(r.TTL),
+			output.FormatBool(r.Proxied),
+			targets[r.ID],
+		})
+	}
+	return out.WriteTable(headers, rows)
+}
+
+// dryRunRecord is the JSON form of a record that was only simulated
+type dryRunRecord struct {
+	DryRun bool              `json:"dry_run"`
+	Record *client.DNSRecord `json:"record"`
+}
+
+// dryRunRecords is the JSON form of several records that were only simulated
+type dryRunRecords struct {
+	DryRun  bool               `json:"dry_run"`
+	Records []client.DNSRecord `json:"records"`
+}
+
+// recordJSON returns the JSON form of a created or updated record, marking
+// results that were only simulated
+func recordJSON(c *client.Client, record *client.DNSRecord) interface{} {
+	if c.DryRun() {
+		return dryRunRecord{DryRun: true, Record: record}
+	}
+	return record
+}
+
+// writeTraces prints captured API exchanges to stderr, pretty-printing JSON bodies
+func writeTraces(traces []client.Trace) {
+	for _, t := range traces {
+		fmt.Fprintf(os.Stderr, "> %s %s\n", t.Method, t.URL)
+		writeTraceHeaders(">", t.RequestHeaders)
+		if len(t.RequestBody) > 0 {
+			fmt.Fprintf(os.Stderr, "%s\n", prettyJSON(t.RequestBody))
+		}
+		fmt.Fprintf(os.Stderr, "<\n< %s\n", t.Status)
+		writeTraceHeaders("<", t.ResponseHeaders)
+		fmt.Fprintf(os.Stderr, "%s\n\n", prettyJSON(t.ResponseBody))
+	}
+}
+
+// writeTraceHeaders prints HTTP headers in a stable order with the given prefix
+func writeTraceHeaders(prefix string, headers http.Header) {
+	var names []string
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "%s %s: %s\n", prefix, name, strings.Join(headers[name], ", "))
+	}
+}
+
+// prettyJSON indents a JSON body, returning it unchanged if it isn't valid JSON
+func prettyJSON(body []byte) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, body, "", "  "); err != nil {
+		return string(body)
+	}
+	return buf.String()
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/coollabsio/cloudflare-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// proxyAudit is the result of auditing the proxy status of a zone
+type proxyAudit struct {
+	Zone      string             `json:"zone"`
+	Proxiable int                `json:"proxiable"`
+	Proxied   int                `json:"proxied"`
+	Records   []client.DNSRecord `json:"records"`
+	Unproxied []client.DNSRecord `json:"unproxied"`
+}
+
+var dnsAuditCmd = &cobra.Command{
+	Use:   "audit <zone>",
+	Short: "Report proxiable DNS records that are not proxied",
+	Long: `List every record in a zone that Cloudflare reports as proxiable (A, AAAA,
+CNAME) and flag the ones that are not proxied. Unproxied records expose their
+origin address, so this helps catch gaps before they become a problem.
+
+Examples:
+  cf dns audit example.com
+  cf dns audit example.com -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		zone, err := resolveZoneDetails(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		records, err := c.ListDNSRecords(ctx, zone.ID, "", "")
+		if err != nil {
+			return err
+		}
+
+		audit := auditProxyStatus(zone.Name, records)
+
+		if outputFormat == "json" {
+			return out.WriteJSON(audit)
+		}
+		out.TeeJSON(audit)
+
+		headers := []string{"ID", "Type", "Name", "Content", "Proxied", "Flag"}
+		var rows [][]string
+		for _, record := range audit.Records {
+			flag := ""
+			if !record.Proxied {
+				flag = "NOT PROXIED"
+			}
+			rows = append(rows, []string{
+				record.ID,
+				record.Type,
+				record.Name,
+				record.Content,
+				output.FormatBool(record.Proxied),
+				flag,
+			})
+		}
+		if err := out.WriteTable(headers, rows); err != nil {
+			return err
+		}
+		out.WriteNote(fmt.Sprintf("\n%d of %d proxiable records are proxied.", audit.Proxied, audit.Proxiable))
+		return nil
+	},
+}
+
+func init() {
+	dnsCmd.AddCommand(dnsAuditCmd)
+}
+
+// auditProxyStatus keeps the records Cloudflare reports as proxiable and counts
+// how many are proxied
+func auditProxyStatus(zoneName string, records []client.DNSRecord) proxyAudit {
+	audit := proxyAudit{Zone: zoneName, Records: []client.DNSRecord{}, Unproxied: []client.DNSRecord{}}
+	for _, r := range records {
+		if !r.Proxiable {
+			continue
+		}
+		audit.Records = append(audit.Records, r)
+		if r.Proxied {
+			audit.Proxied++
+		} else {
+			audit.Unproxied = append(audit.Unproxied, r)
+		}
+	}
+	audit.Proxiable = len(audit.Records)
+	return audit
+}
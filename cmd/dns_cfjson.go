@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/coollabsio/cloudflare-cli/internal/output"
+)
+
+// cfjsonVersion is the version of the cfjson format written by dns export
+const cfjsonVersion = 1
+
+// cfjsonDocument is the cfjson export format: a Cloudflare-specific backup
+// that keeps what BIND zone files cannot carry (proxy status, comments, tags,
+// structured data). Names are relative to the zone ("@" is the apex), so a
+// backup can be restored into another zone.
+type cfjsonDocument struct {
+	Format  string         `json:"format"`
+	Version int            `json:"version"`
+	Zone    string         `json:"zone"`
+	Records []cfjsonRecord `json:"records"`
+}
+
+// cfjsonRecord is one record of a cfjson document
+type cfjsonRecord struct {
+	Type     string                 `json:"type"`
+	Name     string                 `json:"name"`
+	Content  string                 `json:"content,omitempty"`
+	TTL      int                    `json:"ttl"`
+	Proxied  bool                   `json:"proxied"`
+	Priority *uint16                `json:"priority,omitempty"`
+	Comment  string                 `json:"comment,omitempty"`
+	Tags     []string               `json:"tags,omitempty"`
+	Data     map[string]interface{} `json:"data,omitempty"`
+}
+
+// writeCFJSON writes records as a cfjson document
+func writeCFJSON(w io.Writer, zoneName string, records []client.DNSRecord) error {
+	doc := cfjsonDocument{Format: "cfjson", Version: cfjsonVersion, Zone: zoneName, Records: []cfjsonRecord{}}
+	for _, r := range records {
+		doc.Records = append(doc.Records, cfjsonRecord{
+			Type:     r.Type,
+			Name:     relativeName(r.Name, zoneName),
+			Content:  r.Content,
+			TTL:      r.TTL,
+			Proxied:  r.Proxied,
+			Priority: r.Priority,
+			Comment:  r.Comment,
+			Tags:     r.Tags,
+			Data:     r.Data,
+		})
+	}
+	return output.NewJSONEncoder(w, jsonCompact).Encode(doc)
+}
+
+// readCFJSON reads a cfjson document and returns its records as create
+// parameters, with names qualified for zoneName
+func readCFJSON(r io.Reader, zoneName string) ([]client.CreateDNSRecordParams, error) {
+	var doc cfjsonDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse cfjson file: %w", err)
+	}
+	if doc.Format != "cfjson" {
+		return nil, fmt.Errorf("not a cfjson file (format is %q); export one with 'cf dns export --format cfjson'", doc.Format)
+	}
+	if doc.Version > cfjsonVersion {
+		return nil, fmt.Errorf("cfjson version %d is newer than this cf supports (%d); update cf", doc.Version, cfjsonVersion)
+	}
+
+	var records []client.CreateDNSRecordParams
+	for i, r := range doc.Records {
+		if r.Type == "" || r.Name == "" {
+			return nil, fmt.Errorf("cfjson record %d: type and name are required", i+1)
+		}
+		records = append(records, client.CreateDNSRecordParams{
+			Type:     strings.ToUpper(r.Type),
+			Name:     qualifyName(r.Name, zoneName),
+			Content:  r.Content,
+			TTL:      r.TTL,
+			Proxied:  r.Proxied,
+			Priority: r.Priority,
+			Comment:  r.Comment,
+			Tags:     r.Tags,
+			Data:     r.Data,
+		})
+	}
+	return records, nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+)
+
+// comparableRecord is a record without what the API assigns on create, with
+// its name relative to the zone
+type comparableRecord struct {
+	Type     string
+	Name     string
+	Content  string
+	TTL      int
+	Proxied  bool
+	Priority uint16
+	Comment  string
+	Tags     []string
+	Data     map[string]interface{}
+}
+
+// comparableZone returns a zone's records in a stable order for comparison
+func comparableZone(api *mockAPI, zoneName string) []comparableRecord {
+	var records []comparableRecord
+	for _, r := range api.zoneRecords(zoneName) {
+		c := comparableRecord{
+			Type: r.Type, Name: relativeName(r.Name, zoneName), Content: r.Content, TTL: r.TTL,
+			Proxied: r.Proxied != nil && *r.Proxied, Comment: r.Comment, Tags: r.Tags,
+		}
+		if r.Priority != nil {
+			c.Priority = *r.Priority
+		}
+		if data, ok := r.Data.(map[string]interface{}); ok {
+			c.Data = data
+		}
+		records = append(records, c)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Content < b.Content
+	})
+	return records
+}
+
+func TestCFJSONRoundTrip(t *testing.T) {
+	api := newMockAPI(t, "example.com", "example.org")
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "@", Content: "192.0.2.1", TTL: 1, Proxied: cloudflare.BoolPtr(true), Comment: "apex", Tags: []string{"env:prod", "team:web"}})
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "CNAME", Name: "www", Content: "example.com", TTL: 1, Proxied: cloudflare.BoolPtr(true)})
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "MX", Name: "@", Content: "mail.example.com", TTL: 3600, Priority: cloudflare.Uint16Ptr(10), Comment: "primary mail"})
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "TXT", Name: "_dmarc", Content: `"v=DMARC1; p=reject"`, TTL: 300, Tags: []string{"mail"}})
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "SRV", Name: "_sip._tcp", TTL: 300, Data: map[string]interface{}{
+		"priority": float64(10), "weight": float64(5), "port": float64(5060), "target": "sip.example.com",
+	}})
+	dir := t.TempDir()
+	file := filepath.Join(dir, "example.com.cf.json")
+
+	if _, stderr, err := runCmd(t, api, "dns", "export", "example.com", "--format", "cfjson", "--file", file); err != nil {
+		t.Fatalf("export failed: %v\n%s", err, stderr)
+	}
+	if _, stderr, err := runCmd(t, api, "dns", "import", "example.org", file, "--format", "cfjson", "--state-file", filepath.Join(dir, "state.json")); err != nil {
+		t.Fatalf("import failed: %v\n%s", err, stderr)
+	}
+
+	exported, imported := comparableZone(api, "example.com"), comparableZone(api, "example.org")
+	if len(imported) != 5 {
+		t.Fatalf("imported %d records, want 5", len(imported))
+	}
+	if !reflect.DeepEqual(exported, imported) {
+		t.Errorf("imported records differ from the exported ones\nexported: %+v\nimported: %+v", exported, imported)
+	}
+}
+
+func TestCFJSONReadWrite(t *testing.T) {
+	records := []client.DNSRecord{
+		{Type: "A", Name: "example.com", Content: "192.0.2.1", TTL: 1, Proxied: true, Comment: "apex", Tags: []string{"env:prod"}},
+		{Type: "MX", Name: "example.com", Content: "mail.example.com", TTL: 3600, Priority: cloudflare.Uint16Ptr(10)},
+		{Type: "CAA", Name: "example.com", TTL: 300, Data: map[string]interface{}{"flags": float64(0), "tag": "issue", "value": "letsencrypt.org"}},
+	}
+	var buf bytes.Buffer
+	if err := writeCFJSON(&buf, "example.com", records); err != nil {
+		t.Fatal(err)
+	}
+	params, err := readCFJSON(&buf, "example.net")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(params) != len(records) {
+		t.Fatalf("read %d records, want %d", len(params), len(records))
+	}
+	for i, p := range params {
+		r := records[i]
+		want := client.CreateDNSRecordParams{
+			Type: r.Type, Name: "example.net", Content: r.Content, TTL: r.TTL, Proxied: r.Proxied,
+			Priority: r.Priority, Comment: r.Comment, Tags: r.Tags, Data: r.Data,
+		}
+		if !reflect.DeepEqual(p, want) {
+			t.Errorf("record %d = %+v, want %+v", i, p, want)
+		}
+	}
+}
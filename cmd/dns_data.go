@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	dnsData     []string
+	dnsDataJSON string
+	dnsWeight   uint16
+	dnsPort     uint16
+)
+
+// recordDataSchema lists the data fields of a record type that takes
+// structured data instead of a content string
+type recordDataSchema struct {
+	required []string
+	optional []string
+	// numeric fields are sent as numbers, all others as strings
+	numeric []string
+	// flags maps the --priority, --weight, --port, and --content flags to the
+	// data fields they fill when the data is assembled from flags
+	flags map[string]string
+}
+
+// recordDataSchemas are the types with known data fields. Other types accept
+// any fields, with numeric-looking values sent as numbers.
+var recordDataSchemas = map[string]recordDataSchema{
+	"CAA": {
+		required: []string{"flags", "tag", "value"},
+		numeric:  []string{"flags"},
+	},
+	"HTTPS": {
+		required: []string{"priority", "target", "value"},
+		numeric:  []string{"priority"},
+	},
+	"NAPTR": {
+		required: []string{"order", "preference", "flags", "service", "regex", "replacement"},
+		numeric:  []string{"order", "preference"},
+	},
+	"LOC": {
+		required: []string{"lat_degrees", "lat_direction", "long_degrees", "long_direction", "altitude"},
+		optional: []string{"lat_minutes", "lat_seconds", "long_minutes", "long_seconds", "size", "precision_horz", "precision_vert"},
+		numeric:  []string{"lat_degrees", "lat_minutes", "lat_seconds", "long_degrees", "long_minutes", "long_seconds", "altitude", "size", "precision_horz", "precision_vert"},
+	},
+	"SRV": {
+		required: []string{"priority", "weight", "port", "target"},
+		numeric:  []string{"priority", "weight", "port"},
+		flags:    map[string]string{"priority": "priority", "weight": "weight", "port": "port", "content": "target"},
+	},
+	"SSHFP": {
+		required: []string{"algorithm", "type", "fingerprint"},
+		numeric:  []string{"algorithm", "type"},
+	},
+	"URI": {
+		// The priority of a URI record is sent alongside the data, not in it
+		required: []string{"weight", "target"},
+		numeric:  []string{"weight"},
+		flags:    map[string]string{"weight": "weight", "content": "target"},
+	},
+	"SVCB": {
+		required: []string{"priority", "target", "value"},
+		numeric:  []string{"priority"},
+	},
+	"TLSA": {
+		required: []string{"usage", "selector", "matching_type", "certificate"},
+		numeric:  []string{"usage", "selector", "matching_type"},
+	},
+}
+
+// hasRecordData reports whether --data or --data-json was given
+func hasRecordData() bool {
+	return len(dnsData) > 0 || dnsDataJSON != ""
+}
+
+// parseRecordData builds the data map for a record from --data key=value
+// pairs or --data-json, and checks it against the type's known fields
+func parseRecordData(recordType string) (map[string]interface{}, error) {
+	if !hasRecordData() {
+		return nil, nil
+	}
+	if len(dnsData) > 0 && dnsDataJSON != "" {
+		return nil, fmt.Errorf("--data and --data-json cannot be used together")
+	}
+
+	recordType = strings.ToUpper(recordType)
+	schema, known := recordDataSchemas[recordType]
+
+	data := make(map[string]interface{})
+	if dnsDataJSON != "" {
+		if err := json.Unmarshal([]byte(dnsDataJSON), &data); err != nil {
+			return nil, fmt.Errorf("invalid --data-json: %w", err)
+		}
+	}
+	for _, pair := range dnsData {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --data %q: expected key=value", pair)
+		}
+		v, err := dataValue(schema, known, key, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s data field %q: %w", recordType, key, err)
+		}
+		data[key] = v
+	}
+
+	if !known {
+		return data, nil
+	}
+	return data, checkRecordData(recordType, schema, data)
+}
+
+// recordDataFromFlags assembles the data of a record from --weight and --port,
+// plus --priority and --content where the type keeps them in its data (e.g.
+// the target of an SRV record). It returns nil unless --weight or --port is
+// given, and rejects them for types that don't use them.
+func recordDataFromFlags(cmd *cobra.Command, recordType string) (map[string]interface{}, error) {
+	recordType = strings.ToUpper(recordType)
+	schema := recordDataSchemas[recordType]
+	given := false
+	for _, flag := range []string{"weight", "port"} {
+		if !cmd.Flags().Changed(flag) {
+			continue
+		}
+		if _, ok := schema.flags[flag]; !ok {
+			return nil, fmt.Errorf("--%s is not used by %s records", flag, recordType)
+		}
+		given = true
+	}
+	if !given {
+		return nil, nil
+	}
+	if len(dnsContents) > 1 {
+		return nil, fmt.Errorf("only one --content can be used with --weight or --port")
+	}
+
+	data := make(map[string]interface{})
+	values := map[string]uint16{"priority": dnsPriority, "weight": dnsWeight, "port": dnsPort}
+	for flag, field := range schema.flags {
+		switch {
+		case flag == "content":
+			if len(dnsContents) == 1 {
+				data[field] = dnsContents[0]
+			}
+		case cmd.Flags().Changed(flag):
+			data[field] = int64(values[flag])
+		}
+	}
+	if err := checkRecordData(recordType, schema, data); err != nil {
+		var flags []string
+		for _, flag := range slices.Sorted(maps.Keys(schema.flags)) {
+			flags = append(flags, "--"+flag)
+		}
+		return nil, fmt.Errorf("%w (set with %s)", err, strings.Join(flags, ", "))
+	}
+	return data, nil
+}
+
+// checkRecordData checks data against the known fields of a record type
+func checkRecordData(recordType string, schema recordDataSchema, data map[string]interface{}) error {
+	for key := range data {
+		if !slices.Contains(schema.required, key) && !slices.Contains(schema.optional, key) {
+			return fmt.Errorf("unknown %s data field %q (fields: %s)", recordType, key, strings.Join(append(slices.Clone(schema.required), schema.optional...), ", "))
+		}
+	}
+	var missing []string
+	for _, key := range schema.required {
+		if _, ok := data[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s records need data field(s): %s", recordType, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// dataValue converts a --data value to a number for numeric fields; for types
+// without a schema any value that parses as a number is sent as one
+func dataValue(schema recordDataSchema, known bool, key, value string) (interface{}, error) {
+	numeric := known && slices.Contains(schema.numeric, key)
+	if known && !numeric {
+		return value, nil
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f, nil
+	}
+	if numeric {
+		return nil, fmt.Errorf("%q is not a number", value)
+	}
+	return value, nil
+}
@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/coollabsio/cloudflare-cli/internal/output"
+	"github.com/coollabsio/cloudflare-cli/internal/zonefile"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffFormat        string
+	diffIncludeApexNS bool
+	diffAgainstNS     string
+)
+
+// recordChange is one difference between the desired and the actual records
+type recordChange struct {
+	// Action is "add" (only desired), "remove" (only actual), or "change"
+	Action  string            `json:"action"`
+	Desired *client.DNSRecord `json:"desired,omitempty"`
+	Actual  *client.DNSRecord `json:"actual,omitempty"`
+}
+
+// recordDiff is the JSON form of a diff: the changes and their counts
+type recordDiff struct {
+	Zone    string         `json:"zone"`
+	Changes []recordChange `json:"changes"`
+	Summary diffSummary    `json:"summary"`
+}
+
+// diffSummary counts the changes of a diff by action
+type diffSummary struct {
+	Add    int `json:"add"`
+	Remove int `json:"remove"`
+	Change int `json:"change"`
+}
+
+var dnsDiffCmd = &cobra.Command{
+	Use:   "diff <zone> [file]",
+	Short: "Compare a zone file against the records in Cloudflare",
+	Long: `Compare the records in a BIND zone file (the desired state) against the
+zone's records in Cloudflare (the actual state). Records are matched by type,
+name, and content; matched records whose TTL, proxy status, or priority differ
+are reported as changed. Nothing is modified.
+
+SOA records are ignored, and so are apex NS records unless --include-apex-ns
+is given, as for dns import.
+
+With --against-ns, the records served by another authoritative nameserver
+(e.g. your current DNS host before a migration) are compared instead: the
+zone is transferred from it with AXFR, or, if the server refuses, each name
+and type on the Cloudflare side is queried from it (records at other names
+are then not found). They are compared against the zone file if one is
+given, otherwise against the live Cloudflare records. "-" lines are records
+only upstream (lost by moving to Cloudflare), "+" lines records only on the
+Cloudflare side; proxy status is ignored and Cloudflare's automatic TTL
+matches any upstream TTL.
+
+--diff-format selects the layout:
+  unified       "-" lines for Cloudflare, "+" lines for the file (default)
+  side-by-side  a table of desired vs actual
+  json          a structured changeset, e.g. for an approval step in CI
+                (also used with -o json)
+
+-o env and -o template write the side-by-side rows, their default layout;
+unified is text only.
+
+Examples:
+  cf dns diff example.com example.com.zone
+  cf dns diff example.com example.com.zone --diff-format side-by-side
+  cf dns diff example.com example.com.zone --diff-format json | jq '.changes[]'
+  cf dns diff example.com --against-ns ns1.current-host.com
+  cf dns diff example.com example.com.zone --against-ns ns1.current-host.com`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if diffAgainstNS != "" {
+			return cobra.RangeArgs(1, 2)(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := diffFormat
+		machine := outputFormat == "env" || outputFormat == "template"
+		if !cmd.Flags().Changed("diff-format") {
+			switch {
+			case outputFormat == "json":
+				format = "json"
+			case machine:
+				format = "side-by-side"
+			}
+		}
+		if format != "unified" && format != "side-by-side" && format != "json" {
+			return fmt.Errorf("invalid --diff-format: %s (must be 'unified', 'side-by-side', or 'json')", format)
+		}
+		if format == "unified" && machine {
+			return fmt.Errorf("--diff-format unified can't be written as %s output (use side-by-side or json)", outputFormat)
+		}
+
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		zone, err := resolveZoneDetails(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		if diffAgainstNS != "" {
+			return diffAgainstNameserver(ctx, c, zone, args[1:], format)
+		}
+
+		parsed, err := parseDiffFile(args[1], zone.Name)
+		if err != nil {
+			return err
+		}
+
+		actual, err := c.ListDNSRecords(ctx, zone.ID, "", "")
+		if err != nil {
+			return err
+		}
+
+		changes := diffRecords(desiredRecords(parsed), actual)
+		return writeRecordChanges(zone.Name, changes, format, "cloudflare", args[1])
+	},
+}
+
+func init() {
+	dnsDiffCmd.Flags().StringVar(&diffFormat, "diff-format", "unified", "diff layout (unified, side-by-side, json)")
+	dnsDiffCmd.Flags().BoolVar(&diffIncludeApexNS, "include-apex-ns", false, "also compare NS records at the zone apex")
+	dnsDiffCmd.Flags().StringVar(&diffAgainstNS, "against-ns", "", "compare against the records served by this nameserver (AXFR, or per-record queries)")
+	dnsCmd.AddCommand(dnsDiffCmd)
+}
+
+// parseDiffFile reads the zone file given to dns diff
+func parseDiffFile(path, zoneName string) ([]client.CreateDNSRecordParams, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zone file: %w", err)
+	}
+	defer f.Close()
+	return zonefile.Parse(f, zoneName, zonefile.Options{IncludeApexNS: diffIncludeApexNS})
+}
+
+// diffAgainstNameserver compares the records served by --against-ns (the
+// actual side) with the zone file in args, or the live Cloudflare records
+func diffAgainstNameserver(ctx context.Context, c *client.Client, zone *client.Zone, args []string, format string) error {
+	var records []client.DNSRecord
+	label := "cloudflare"
+	if len(args) > 0 {
+		parsed, err := parseDiffFile(args[0], zone.Name)
+		if err != nil {
+			return err
+		}
+		records = desiredRecords(parsed)
+		label = args[0]
+	} else {
+		live, err := c.ListDNSRecords(ctx, zone.ID, "", "")
+		if err != nil {
+			return err
+		}
+		for _, r := range live {
+			// Cloudflare's own apex nameservers never match the old host's
+			if strings.EqualFold(r.Type, "NS") && strings.EqualFold(r.Name, zone.Name) && !diffIncludeApexNS {
+				continue
+			}
+			records = append(records, r)
+		}
+	}
+	for i := range records {
+		records[i].Proxied = false
+	}
+
+	opts := zonefile.Options{IncludeApexNS: diffIncludeApexNS}
+	stop := startSpinner(fmt.Sprintf("Reading %s from %s...", zone.Name, diffAgainstNS))
+	upstream, err := zonefile.Transfer(diffAgainstNS, zone.Name, opts)
+	if err != nil {
+		stop()
+		fmt.Fprintf(os.Stderr, "Warning: %v; querying each record instead (records at other names will not be found)\n", err)
+		stop = startSpinner(fmt.Sprintf("Querying %s...", diffAgainstNS))
+		var questions []zonefile.Question
+		for _, r := range records {
+			questions = append(questions, zonefile.Question{Name: r.Name, Type: r.Type})
+		}
+		upstream, err = zonefile.Lookup(ctx, diffAgainstNS, zone.Name, questions, opts)
+	}
+	stop()
+	if err != nil {
+		return err
+	}
+
+	changes := diffRecords(records, desiredRecords(upstream))
+	// An automatic TTL on the Cloudflare side matches whatever the upstream serves
+	changes = slices.DeleteFunc(changes, func(ch recordChange) bool {
+		if ch.Action != "change" || ch.Desired.TTL != 1 {
+			return false
+		}
+		relaxed := *ch.Desired
+		relaxed.TTL = ch.Actual.TTL
+		return sameRecordSettings(relaxed, *ch.Actual)
+	})
+	return writeRecordChanges(zone.Name, changes, format, diffAgainstNS, label)
+}
+
+// desiredRecords converts parsed records to the DNSRecord shape used for diffing
+func desiredRecords(params []client.CreateDNSRecordParams) []client.DNSRecord {
+	var records []client.DNSRecord
+	for _, p := range params {
+		records = append(records, client.DNSRecord{
+			Type:     p.Type,
+			Name:     p.Name,
+			Content:  p.Content,
+			TTL:      p.TTL,
+			Proxied:  p.Proxied,
+			Priority: p.Priority,
+			Comment:  p.Comment,
+		})
+	}
+	return records
+}
+
+// diffRecords matches desired and actual records by type, name, and content
+// (compared with comparableContent, so TXT quoting doesn't matter) and returns
+// what differs, in desired order followed by actual-only records
+func diffRecords(desired, actual []client.DNSRecord) []recordChange {
+	byKey := make(map[string][]int)
+	for i, r := range actual {
+		key := importKey(r.Type, r.Name, comparableContent(r.Type, r.Content))
+		byKey[key] = append(byKey[key], i)
+	}
+
+	var changes []recordChange
+	matched := make(map[int]bool)
+	for i := range desired {
+		d := &desired[i]
+		key := importKey(d.Type, d.Name, comparableContent(d.Type, d.Content))
+		if len(byKey[key]) == 0 {
+			changes = append(changes, recordChange{Action: "add", Desired: d})
+			continue
+		}
+		j := byKey[key][0]
+		byKey[key] = byKey[key][1:]
+		matched[j] = true
+		if !sameRecordSettings(*d, actual[j]) {
+			changes = append(changes, recordChange{Action: "change", Desired: d, Actual: &actual[j]})
+		}
+	}
+	for j := range actual {
+		if !matched[j] {
+			changes = append(changes, recordChange{Action: "remove", Actual: &actual[j]})
+		}
+	}
+	return changes
+}
+
+// sameRecordSettings compares the settings of two records with the same type, name, and content
+func sameRecordSettings(a, b client.DNSRecord) bool {
+	if (a.Priority == nil) != (b.Priority == nil) {
+		return false
+	}
+	if a.Priority != nil && *a.Priority != *b.Priority {
+		return false
+	}
+	return a.TTL == b.TTL && a.Proxied == b.Proxied
+}
+
+// writeRecordChanges renders changes in the given diff format. actualLabel and
+// desiredLabel name the two sides in the unified header.
+func writeRecordChanges(zoneName string, changes []recordChange, format, actualLabel, desiredLabel string) error {
+	counts := map[string]int{}
+	for _, ch := range changes {
+		counts[ch.Action]++
+	}
+
+	if changes == nil {
+		changes = []recordChange{}
+	}
+	result := recordDiff{
+		Zone:    zoneName,
+		Changes: changes,
+		Summary: diffSummary{Add: counts["add"], Remove: counts["remove"], Change: counts["change"]},
+	}
+	if format == "json" {
+		return out.WriteJSON(result)
+	}
+	out.TeeJSON(result)
+
+	if len(changes) == 0 {
+		out.WriteSuccess("No differences")
+		return nil
+	}
+
+	if format == "side-by-side" {
+		headers := []string{"Action", "Desired", "Actual"}
+		var rows [][]string
+		for _, ch := range changes {
+			rows = append(rows, []string{ch.Action, describeRecord(ch.Desired), describeRecord(ch.Actual)})
+		}
+		if err := out.WriteTable(headers, rows); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("--- %s (%s)\n+++ %s\n", actualLabel, zoneName, desiredLabel)
+		for _, ch := range changes {
+			if ch.Actual != nil {
+				fmt.Printf("- %s\n", describeRecord(ch.Actual))
+			}
+			if ch.Desired != nil {
+				fmt.Printf("+ %s\n", describeRecord(ch.Desired))
+			}
+		}
+	}
+	out.WriteNote(fmt.Sprintf("\n%d to add, %d to remove, %d to change", counts["add"], counts["remove"], counts["change"]))
+	return nil
+}
+
+// describeRecord renders a record on one line for diffs, or "" for nil
+func describeRecord(r *client.DNSRecord) string {
+	if r == nil {
+		return ""
+	}
+	parts := []string{strings.ToUpper(r.Type), r.Name, r.Content, "ttl=" + output.FormatTTL(r.TTL)}
+	if r.Priority != nil {
+		parts = append(parts, fmt.Sprintf("priority=%d", *r.Priority))
+	}
+	if r.Proxied {
+		parts = append(parts, "proxied")
+	}
+	return strings.Join(parts, " ")
+}
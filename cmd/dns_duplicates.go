@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+)
+
+// duplicateGroup is a set of records that share a name and either a type or a
+// CNAME conflict, as reported by dns list --duplicates
+type duplicateGroup struct {
+	Name    string             `json:"name"`
+	Type    string             `json:"type"`
+	Issue   string             `json:"issue"`
+	Records []client.DNSRecord `json:"records"`
+}
+
+// findDuplicates groups records by name and type and returns the groups with
+// more than one record, plus names where a CNAME shares its name with other
+// records. At the zone apex Cloudflare flattens CNAMEs, so there only A and
+// AAAA records conflict with one, as in checkCNAMEConflict.
+func findDuplicates(records []client.DNSRecord, zoneName string) []duplicateGroup {
+	type key struct{ name, recordType string }
+	groups := make(map[key][]client.DNSRecord)
+	byName := make(map[string][]client.DNSRecord)
+	for _, r := range records {
+		name := strings.ToLower(r.Name)
+		k := key{name, strings.ToUpper(r.Type)}
+		groups[k] = append(groups[k], r)
+		byName[name] = append(byName[name], r)
+	}
+
+	var result []duplicateGroup
+	for k, rs := range groups {
+		if len(rs) < 2 {
+			continue
+		}
+		issue := fmt.Sprintf("%d records", len(rs))
+		for i := range rs {
+			if slices.ContainsFunc(rs[i+1:], func(r client.DNSRecord) bool { return sameContent(r.Type, r.Content, rs[i].Content) }) {
+				issue = fmt.Sprintf("%d records, identical content", len(rs))
+				break
+			}
+		}
+		result = append(result, duplicateGroup{Name: rs[0].Name, Type: k.recordType, Issue: issue, Records: rs})
+	}
+
+	for name, rs := range byName {
+		apex := strings.EqualFold(name, zoneName)
+		if !slices.ContainsFunc(rs, func(r client.DNSRecord) bool { return strings.EqualFold(r.Type, "CNAME") }) {
+			continue
+		}
+		var conflicting []client.DNSRecord
+		var types []string
+		for _, r := range rs {
+			t := strings.ToUpper(r.Type)
+			if t == "CNAME" || (apex && t != "A" && t != "AAAA") {
+				continue
+			}
+			conflicting = append(conflicting, r)
+			if !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+		if len(conflicting) == 0 {
+			continue
+		}
+		for _, r := range rs {
+			if strings.EqualFold(r.Type, "CNAME") {
+				conflicting = append([]client.DNSRecord{r}, conflicting...)
+			}
+		}
+		result = append(result, duplicateGroup{
+			Name:    rs[0].Name,
+			Type:    "CNAME",
+			Issue:   "CNAME conflicts with " + strings.Join(types, ", "),
+			Records: conflicting,
+		})
+	}
+
+	slices.SortFunc(result, func(a, b duplicateGroup) int {
+		return cmp.Or(cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)), cmp.Compare(a.Type, b.Type), cmp.Compare(a.Issue, b.Issue))
+	})
+	return result
+}
+
+// writeDuplicates writes the duplicate and conflicting records of a zone, one
+// row per record with the issue of its group
+func writeDuplicates(records []client.DNSRecord, zoneName string) error {
+	groups := findDuplicates(records, zoneName)
+	if outputFormat == "json" {
+		if groups == nil {
+			groups = []duplicateGroup{}
+		}
+		return out.WriteJSON(groups)
+	}
+	out.TeeJSON(groups)
+	if len(groups) == 0 {
+		out.WriteSuccess(fmt.Sprintf("No duplicate or conflicting records among %d record(s)", len(records)))
+		return nil
+	}
+
+	headers := []string{"Issue", "ID", "Type", "Name", "Content"}
+	var rows [][]string
+	for _, g := range groups {
+		for _, r := range g.Records {
+			rows = append(rows, []string{g.Issue, r.ID, r.Type, displayName(r.Name, zoneName), r.Content})
+		}
+	}
+	if err := out.WriteTable(headers, rows); err != nil {
+		return err
+	}
+	out.WriteNote(fmt.Sprintf("\n%d group(s) of duplicate or conflicting records", len(groups)))
+	return nil
+}
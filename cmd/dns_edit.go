@@ -0,0 +1,373 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// editRecord is the YAML representation of a DNS record used by dns edit
+type editRecord struct {
+	ID       string   `yaml:"id,omitempty"`
+	Type     string   `yaml:"type"`
+	Name     string   `yaml:"name"`
+	Content  string   `yaml:"content"`
+	TTL      int      `yaml:"ttl"`
+	Proxied  bool     `yaml:"proxied"`
+	Priority *uint16  `yaml:"priority,omitempty"`
+	Comment  string   `yaml:"comment,omitempty"`
+	Tags     []string `yaml:"tags,omitempty"`
+}
+
+// editChange is a single planned change computed from the edited file
+type editChange struct {
+	Action string
+	Record editRecord
+}
+
+const editHeader = `# Edit the DNS records for %s.
+#
+#   - Change a field to update the record
+#   - Remove an entry to delete the record
+#   - Add an entry without an id to create a record
+#
+# Save and close the editor to review the changes. Leave the file unchanged to abort.
+`
+
+var dnsEditCmd = &cobra.Command{
+	Use:   "edit <zone>",
+	Short: "Edit a zone's DNS records as YAML",
+	Long: `Open all DNS records for a zone as YAML in your editor ($VISUAL, $EDITOR, or vi).
+
+After saving, the changes are compared against the current records and shown
+as a plan of creates, updates, and deletes. The plan is applied after
+confirmation (use --yes to skip). Nothing is changed if the file is unchanged.
+
+A plan that deletes more than --confirm-threshold records (default 10) also
+asks you to type DELETE, even with --yes.
+
+A file that fails to parse is an error and changes nothing. Removing every
+record, or keeping fewer than --min-keep-ratio of them (default 0.5), is
+refused unless --allow-empty is given, so an emptied or truncated file cannot
+wipe the zone.
+
+Examples:
+  cf dns edit example.com
+  EDITOR=nano cf dns edit example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		zoneID, err := resolveZone(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		records, err := c.ListDNSRecords(ctx, zoneID, "", "")
+		if err != nil {
+			return err
+		}
+
+		var current []editRecord
+		for _, r := range records {
+			current = append(current, editRecordFromDNS(r))
+		}
+
+		body, err := yaml.Marshal(current)
+		if err != nil {
+			return fmt.Errorf("failed to encode records: %w", err)
+		}
+		original := append([]byte(fmt.Sprintf(editHeader, args[0])), body...)
+
+		edited, err := editInEditor(original)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(original, edited) {
+			out.WriteSuccess("No changes made")
+			return nil
+		}
+
+		var desired []editRecord
+		if err := yaml.Unmarshal(edited, &desired); err != nil {
+			return fmt.Errorf("failed to parse edited records: %w", err)
+		}
+
+		changes, err := planEditChanges(current, desired)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			out.WriteSuccess("No changes to apply")
+			return nil
+		}
+
+		headers := []string{"Action", "ID", "Type", "Name", "Content"}
+		var rows [][]string
+		for _, ch := range changes {
+			rows = append(rows, []string{ch.Action, ch.Record.ID, ch.Record.Type, ch.Record.Name, ch.Record.Content})
+		}
+		if err := out.WriteTable(headers, rows); err != nil {
+			return err
+		}
+
+		deletes := 0
+		for _, ch := range changes {
+			if ch.Action == "delete" {
+				deletes++
+			}
+		}
+		if err := checkPruneGuard(len(current), len(current)-deletes); err != nil {
+			return err
+		}
+
+		if !dnsYes && !c.DryRun() && !confirm(fmt.Sprintf("Apply %d change(s)?", len(changes))) {
+			return errors.New("aborted: no changes applied")
+		}
+		if err := confirmLargeDelete(deletes, c.DryRun()); err != nil {
+			return err
+		}
+
+		failed := 0
+		if len(changes) > client.BatchThreshold {
+			failed = applyEditBatch(ctx, c, zoneID, changes)
+		} else {
+			for _, ch := range changes {
+				if stopAfterFailure(failed) {
+					out.WriteError(fmt.Errorf("%s %s %s: %s", ch.Action, ch.Record.Type, ch.Record.Name, skippedAfterFailure))
+					failed++
+					continue
+				}
+				if err := applyEditChange(ctx, c, zoneID, ch); err != nil {
+					out.WriteError(fmt.Errorf("%s %s %s: %w", ch.Action, ch.Record.Type, ch.Record.Name, err))
+					failed++
+				}
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d change(s) failed", failed, len(changes))
+		}
+		if c.DryRun() {
+			out.WriteSuccess(fmt.Sprintf("(dry-run) Would apply %d change(s)", len(changes)))
+			return nil
+		}
+		out.WriteSuccess(fmt.Sprintf("Applied %d change(s)", len(changes)))
+		return nil
+	},
+}
+
+func init() {
+	dnsEditCmd.Flags().BoolVarP(&dnsYes, "yes", "y", false, "apply changes without confirmation")
+	addBulkErrorFlags(dnsEditCmd)
+	addConfirmThresholdFlag(dnsEditCmd)
+	addPruneGuardFlags(dnsEditCmd)
+	dnsCmd.AddCommand(dnsEditCmd)
+}
+
+// editRecordFromDNS converts a DNS record to its editable form
+func editRecordFromDNS(r client.DNSRecord) editRecord {
+	return editRecord{
+		ID:       r.ID,
+		Type:     r.Type,
+		Name:     r.Name,
+		Content:  r.Content,
+		TTL:      r.TTL,
+		Proxied:  r.Proxied,
+		Priority: r.Priority,
+		Comment:  r.Comment,
+		Tags:     r.Tags,
+	}
+}
+
+// planEditChanges compares the current records against the edited set.
+// Records are matched by ID: new entries without an ID are created, entries
+// whose fields differ are updated, and current records missing from the
+// edited set are deleted.
+func planEditChanges(current, desired []editRecord) ([]editChange, error) {
+	byID := make(map[string]editRecord)
+	for _, r := range current {
+		byID[r.ID] = r
+	}
+
+	var changes []editChange
+	seen := make(map[string]bool)
+	for _, r := range desired {
+		if r.Type == "" || r.Name == "" || r.Content == "" {
+			return nil, fmt.Errorf("record %q is missing type, name, or content", r.Name)
+		}
+		if r.TTL == 0 {
+			r.TTL = 1
+		}
+
+		if r.ID == "" {
+			changes = append(changes, editChange{Action: "create", Record: r})
+			continue
+		}
+
+		existing, ok := byID[r.ID]
+		if !ok {
+			return nil, fmt.Errorf("unknown record id %q (remove the id to create a new record)", r.ID)
+		}
+		if seen[r.ID] {
+			return nil, fmt.Errorf("record id %q appears more than once", r.ID)
+		}
+		seen[r.ID] = true
+
+		if !editRecordsEqual(existing, r) {
+			changes = append(changes, editChange{Action: "update", Record: r})
+		}
+	}
+
+	for _, r := range current {
+		if !seen[r.ID] {
+			changes = append(changes, editChange{Action: "delete", Record: r})
+		}
+	}
+
+	return changes, nil
+}
+
+// editRecordsEqual reports whether two editable records have identical fields,
+// comparing content as sameContent does
+func editRecordsEqual(a, b editRecord) bool {
+	if (a.Priority == nil) != (b.Priority == nil) {
+		return false
+	}
+	if a.Priority != nil && *a.Priority != *b.Priority {
+		return false
+	}
+	return strings.EqualFold(a.Type, b.Type) &&
+		a.Name == b.Name &&
+		sameContent(a.Type, a.Content, b.Content) &&
+		a.TTL == b.TTL &&
+		a.Proxied == b.Proxied &&
+		a.Comment == b.Comment &&
+		slices.Equal(a.Tags, b.Tags)
+}
+
+// applyEditChange performs a single planned change against the API
+func applyEditChange(ctx context.Context, c *client.Client, zoneID string, ch editChange) error {
+	r := ch.Record
+	switch ch.Action {
+	case "create":
+		_, err := c.CreateDNSRecord(ctx, zoneID, client.CreateDNSRecordParams{
+			Type:     r.Type,
+			Name:     r.Name,
+			Content:  r.Content,
+			TTL:      r.TTL,
+			Proxied:  r.Proxied,
+			Priority: r.Priority,
+			Comment:  r.Comment,
+			Tags:     r.Tags,
+		})
+		return err
+	case "update":
+		_, err := c.UpdateDNSRecord(ctx, zoneID, r.ID, client.UpdateDNSRecordParams{
+			Type:     r.Type,
+			Name:     r.Name,
+			Content:  r.Content,
+			TTL:      &r.TTL,
+			Proxied:  &r.Proxied,
+			Priority: r.Priority,
+			Comment:  &r.Comment,
+			Tags:     r.Tags,
+		})
+		return err
+	case "delete":
+		return c.DeleteDNSRecord(ctx, zoneID, r.ID)
+	}
+	return fmt.Errorf("unknown action: %s", ch.Action)
+}
+
+// applyEditBatch applies the planned changes through the batch endpoint and
+// returns how many of them were not applied
+func applyEditBatch(ctx context.Context, c *client.Client, zoneID string, changes []editChange) int {
+	var params client.BatchDNSRecordsParams
+	for _, ch := range changes {
+		r := ch.Record
+		switch ch.Action {
+		case "create":
+			params.Posts = append(params.Posts, client.CreateDNSRecordParams{
+				Type:     r.Type,
+				Name:     r.Name,
+				Content:  r.Content,
+				TTL:      r.TTL,
+				Proxied:  r.Proxied,
+				Priority: r.Priority,
+				Comment:  r.Comment,
+				Tags:     r.Tags,
+			})
+		case "update":
+			params.Patches = append(params.Patches, client.BatchPatch{ID: r.ID, Params: client.UpdateDNSRecordParams{
+				Type:     r.Type,
+				Name:     r.Name,
+				Content:  r.Content,
+				TTL:      &r.TTL,
+				Proxied:  &r.Proxied,
+				Priority: r.Priority,
+				Comment:  &r.Comment,
+				Tags:     r.Tags,
+			}})
+		case "delete":
+			params.Deletes = append(params.Deletes, r.ID)
+		}
+	}
+
+	result, err := c.BatchDNSRecords(ctx, zoneID, params)
+	if err == nil {
+		return 0
+	}
+	out.WriteError(err)
+	return len(changes) - len(result.Deletes) - len(result.Patches) - len(result.Posts)
+}
+
+// editInEditor writes content to a temp file, opens it in the user's editor,
+// and returns the saved content
+func editInEditor(content []byte) ([]byte, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	f, err := os.CreateTemp("", "cf-dns-edit-*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	parts := strings.Fields(editor)
+	editCmd := exec.Command(parts[0], append(parts[1:], f.Name())...)
+	editCmd.Stdin = os.Stdin
+	editCmd.Stdout = os.Stdout
+	editCmd.Stderr = os.Stderr
+	if err := editCmd.Run(); err != nil {
+		return nil, fmt.Errorf("editor failed: %w", err)
+	}
+
+	return os.ReadFile(f.Name())
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var existsQuiet bool
+
+// errNoMatchingRecord is returned by dns exists when nothing matches
+var errNoMatchingRecord = errors.New("no matching DNS record found")
+
+var dnsExistsCmd = &cobra.Command{
+	Use:   "exists <zone>",
+	Short: "Check whether a matching DNS record exists",
+	Long: `Check whether a DNS record matching the given name, type, and/or content
+exists. Exits 0 if at least one record matches and non-zero otherwise, so it
+can be used directly in health checks and scripts.
+
+Any subset of --name, --type, and --content can be given; names may be
+relative to the zone. Use --quiet to print nothing and rely on the exit code.
+
+Examples:
+  cf dns exists example.com --name www --type A --content 192.0.2.1
+  cf dns exists example.com --name _dmarc --type TXT --quiet && echo present`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if dnsName == "" && !dnsApex && dnsType == "" && dnsContent == "" {
+			return fmt.Errorf("at least one of --name, --apex, --type, or --content is required")
+		}
+
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		zone, err := resolveZoneDetails(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		records, err := c.FindDNSRecords(ctx, zone.ID, recordName(zone.Name), dnsType)
+		if err != nil {
+			return err
+		}
+
+		var matches []client.DNSRecord
+		for _, r := range records {
+			if dnsContent == "" || sameContent(r.Type, r.Content, dnsContent) {
+				matches = append(matches, r)
+			}
+		}
+
+		// From here on a failure means "not found", not a usage problem
+		cmd.SilenceUsage = true
+		if existsQuiet {
+			cmd.SilenceErrors = true
+		} else if outputFormat == "json" {
+			if err := out.WriteJSON(existsResult(matches)); err != nil {
+				return err
+			}
+		} else {
+			out.TeeJSON(existsResult(matches))
+			if len(matches) > 0 {
+				out.WriteSuccess(fmt.Sprintf("%d matching DNS record(s) found", len(matches)))
+			}
+		}
+
+		if len(matches) == 0 {
+			return errNoMatchingRecord
+		}
+		return nil
+	},
+}
+
+func init() {
+	dnsExistsCmd.Flags().StringVarP(&dnsName, "name", "n", "", "record name to match")
+	addApexFlag(dnsExistsCmd)
+	dnsExistsCmd.Flags().StringVarP(&dnsType, "type", "t", "", "record type to match")
+	dnsExistsCmd.Flags().StringVarP(&dnsContent, "content", "c", "", "record content to match")
+	dnsExistsCmd.Flags().BoolVarP(&existsQuiet, "quiet", "q", false, "print nothing; report the result only through the exit code")
+	dnsCmd.AddCommand(dnsExistsCmd)
+}
+
+// recordExists is the JSON form of the dns exists answer
+type recordExists struct {
+	Exists  bool               `json:"exists"`
+	Records []client.DNSRecord `json:"records"`
+}
+
+// existsResult is the JSON form of the dns exists answer
+func existsResult(matches []client.DNSRecord) recordExists {
+	if matches == nil {
+		matches = []client.DNSRecord{}
+	}
+	return recordExists{Exists: len(matches) > 0, Records: matches}
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/coollabsio/cloudflare-cli/internal/output"
+	"github.com/coollabsio/cloudflare-cli/internal/zonefile"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat string
+	exportFile   string
+	exportOrder  string
+	exportDir    string
+	exportSplit  bool
+)
+
+// exportFormats are the accepted --format values
+var exportFormats = []string{"bind", "json", "cfjson"}
+
+// exportOrders are the accepted --order values
+var exportOrders = []string{"api", "name", "type", "registrar"}
+
+var dnsExportCmd = &cobra.Command{
+	Use:   "export <zone>",
+	Short: "Export DNS records",
+	Long: `Export a zone's DNS records as a BIND zone file (default) or JSON.
+
+--format cfjson writes a Cloudflare-specific JSON backup that keeps proxy
+status, comments, tags, priorities, and structured data, which BIND zone files
+cannot carry. Names are relative to the zone, and 'dns import --format cfjson'
+restores it, into the same or another zone.
+
+The same filters as dns list can be used to export a subset of records.
+Records are ordered with --order:
+  registrar  by name (apex first), then SOA/NS, A/AAAA, and other types (default)
+  name       by name, keeping API order within a name
+  type       by type, then name
+  api        in the order the API returns them
+
+With --dir, the export is written to <zone>.zone (.json, .cf.json) in that
+directory. Add --split-by-type to write one file per record type instead
+(A.zone, CNAME.zone, TXT.zone, ...), e.g. to review or re-import a subset.
+Records at the zone apex go in the file of their type like all others, and
+NS records (including the apex's) in NS.zone. A manifest.json listing the
+files written and their record counts is added to the directory.
+
+Note: a filtered export is not a complete zone and should not be re-imported
+as the authoritative record set.
+
+Examples:
+  cf dns export example.com > example.com.zone
+  cf dns export example.com --format json --file records.json
+  cf dns export example.com --format cfjson --file example.com.cf.json
+  cf dns export example.com --type TXT
+  cf dns export example.com --dir backup/ --split-by-type
+  cf dns export example.com --name-contains staging --proxied`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateExportFormat(); err != nil {
+			return err
+		}
+		if err := validateExportOrder(); err != nil {
+			return err
+		}
+		if exportSplit && exportDir == "" {
+			return fmt.Errorf("--split-by-type needs --dir")
+		}
+		if exportDir != "" && exportFile != "" {
+			return fmt.Errorf("--dir and --file cannot be used together")
+		}
+
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		zone, err := resolveZoneDetails(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		records, err := c.ListDNSRecords(ctx, zone.ID, dnsType, recordName(zone.Name))
+		if err != nil {
+			return err
+		}
+
+		records, err = filterDNSRecords(records, zone.Name)
+		if err != nil {
+			return err
+		}
+
+		if dnsType != "" || dnsName != "" || dnsApex || dnsContains != "" || dnsSearch != "" || dnsProxied != "" || dnsNamesFile != "" {
+			fmt.Fprintln(os.Stderr, "Note: this is a filtered export and does not contain the complete zone.")
+		}
+
+		if exportDir != "" {
+			return writeExportDir(zone.Name, records)
+		}
+
+		var w io.Writer = os.Stdout
+		if exportFile != "" {
+			f, err := os.Create(exportFile)
+			if err != nil {
+				return fmt.Errorf("failed to create export file: %w", err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if err := writeExport(w, zone.Name, records); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+
+		if exportFile != "" {
+			fmt.Fprintf(os.Stderr, "Exported %d record(s) to %s\n", len(records), exportFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	addDNSFilterFlags(dnsExportCmd)
+	dnsExportCmd.Flags().StringVar(&exportFormat, "format", "bind", "export format (bind, json, cfjson)")
+	dnsExportCmd.Flags().StringVarP(&exportFile, "file", "f", "", "write the export to a file instead of stdout")
+	dnsExportCmd.Flags().StringVar(&exportOrder, "order", "registrar", "record order (api, name, type, registrar)")
+	dnsExportCmd.Flags().StringVar(&exportDir, "dir", "", "write the export into this directory")
+	dnsExportCmd.Flags().BoolVar(&exportSplit, "split-by-type", false, "with --dir, write one file per record type and a manifest")
+	dnsCmd.AddCommand(dnsExportCmd)
+}
+
+// writeExport serializes records in the selected export format and order
+func writeExport(w io.Writer, zoneName string, records []client.DNSRecord) error {
+	records = orderRecords(records, zoneName, exportOrder)
+	switch exportFormat {
+	case "json":
+		if records == nil {
+			records = []client.DNSRecord{}
+		}
+		return output.NewJSONEncoder(w, jsonCompact).Encode(records)
+	case "cfjson":
+		return writeCFJSON(w, zoneName, records)
+	}
+	return zonefile.Write(w, zoneName, records)
+}
+
+// exportExtension returns the file extension of the selected export format
+func exportExtension() string {
+	switch exportFormat {
+	case "json":
+		return ".json"
+	case "cfjson":
+		return ".cf.json"
+	}
+	return ".zone"
+}
+
+// validateExportFormat checks the --format flag
+func validateExportFormat() error {
+	if !slices.Contains(exportFormats, exportFormat) {
+		return fmt.Errorf("invalid --format: %s (must be one of %s)", exportFormat, strings.Join(exportFormats, ", "))
+	}
+	return nil
+}
+
+// validateExportOrder checks the --order flag
+func validateExportOrder() error {
+	if !slices.Contains(exportOrders, exportOrder) {
+		return fmt.Errorf("invalid --order: %s (must be one of %s)", exportOrder, strings.Join(exportOrders, ", "))
+	}
+	return nil
+}
+
+// orderRecords returns a sorted copy of records. Sorting is stable, so records
+// that compare equal keep their API order.
+func orderRecords(records []client.DNSRecord, zoneName, order string) []client.DNSRecord {
+	if order == "api" {
+		return records
+	}
+
+	sorted := slices.Clone(records)
+	byName := func(a, b client.DNSRecord) int {
+		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	}
+	switch order {
+	case "name":
+		slices.SortStableFunc(sorted, byName)
+	case "type":
+		slices.SortStableFunc(sorted, func(a, b client.DNSRecord) int {
+			return cmp.Or(cmp.Compare(strings.ToUpper(a.Type), strings.ToUpper(b.Type)), byName(a, b))
+		})
+	case "registrar":
+		slices.SortStableFunc(sorted, func(a, b client.DNSRecord) int {
+			return cmp.Or(
+				compareApexFirst(a.Name, b.Name, zoneName),
+				byName(a, b),
+				cmp.Compare(registrarTypeRank(a.Type), registrarTypeRank(b.Type)),
+				cmp.Compare(strings.ToUpper(a.Type), strings.ToUpper(b.Type)),
+			)
+		})
+	}
+	return sorted
+}
+
+// compareApexFirst orders records at the zone apex before all others
+func compareApexFirst(a, b, zoneName string) int {
+	aApex := strings.EqualFold(strings.TrimSuffix(a, "."), zoneName)
+	bApex := strings.EqualFold(strings.TrimSuffix(b, "."), zoneName)
+	switch {
+	case aApex && !bApex:
+		return -1
+	case bApex && !aApex:
+		return 1
+	}
+	return 0
+}
+
+// registrarTypeRank puts SOA and NS first, then address records, then the rest
+func registrarTypeRank(recordType string) int {
+	switch strings.ToUpper(recordType) {
+	case "SOA":
+		return 0
+	case "NS":
+		return 1
+	case "A":
+		return 2
+	case "AAAA":
+		return 3
+	}
+	return 4
+}
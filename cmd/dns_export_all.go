@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportAllDir         string
+	exportAllConcurrency int
+)
+
+// exportAllResult is the outcome of exporting a single zone
+type exportAllResult struct {
+	Zone    string `json:"zone"`
+	Records int    `json:"records"`
+	File    string `json:"file,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+var dnsExportAllCmd = &cobra.Command{
+	Use:   "export-all",
+	Short: "Export the DNS records of every zone",
+	Long: `Export the DNS records of every accessible zone into a directory, one file
+per zone (<zone>.zone for BIND, <zone>.json for JSON, <zone>.cf.json for
+cfjson).
+
+Zones are exported in parallel by a bounded pool of workers (--concurrency).
+By default a failure in one zone does not stop the others and failures are
+reported at the end; use --fail-fast to stop starting new exports after the
+first failure.
+
+Examples:
+  cf dns export-all --dir backups/
+  cf dns export-all --dir backups/ --format json --concurrency 8`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateExportFormat(); err != nil {
+			return err
+		}
+		if err := validateExportOrder(); err != nil {
+			return err
+		}
+		if exportAllConcurrency < 1 {
+			return fmt.Errorf("--concurrency must be at least 1")
+		}
+		if err := os.MkdirAll(exportAllDir, 0755); err != nil {
+			return fmt.Errorf("failed to create export directory: %w", err)
+		}
+
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		stop := startSpinner("Fetching zones...")
+		zones, err := c.ListZones(ctx)
+		stop()
+		if err != nil {
+			return err
+		}
+
+		// Results are written by index, so workers never share a slot
+		results := make([]exportAllResult, len(zones))
+		jobs := make(chan int)
+		var wg sync.WaitGroup
+		var stopped atomic.Bool
+		for w := 0; w < min(exportAllConcurrency, len(zones)); w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range jobs {
+					if stopped.Load() {
+						results[i] = exportAllResult{Zone: zones[i].Name, Error: skippedAfterFailure}
+						continue
+					}
+					results[i] = exportZone(ctx, c, zones[i])
+					if results[i].Error != "" && stopAfterFailure(1) {
+						stopped.Store(true)
+					}
+				}
+			}()
+		}
+		for i := range zones {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+
+		failed := 0
+		for _, r := range results {
+			if r.Error != "" {
+				failed++
+			}
+		}
+
+		shown := onlyFailed(results, func(r exportAllResult) bool { return r.Error != "" })
+		if outputFormat == "json" {
+			if err := out.WriteJSON(shown); err != nil {
+				return err
+			}
+		} else {
+			out.TeeJSON(shown)
+			headers := []string{"Zone", "Records", "File", "Error"}
+			var rows [][]string
+			for _, r := range results {
+				rows = append(rows, []string{r.Zone, fmt.Sprintf("%d", r.Records), r.File, r.Error})
+			}
+			if err := writeBulkResults(headers, rows); err != nil {
+				return err
+			}
+			out.WriteNote(fmt.Sprintf("\n%d of %d zone(s) exported, %d failed", len(zones)-failed, len(zones), failed))
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d zone(s) failed to export", failed, len(zones))
+		}
+		return nil
+	},
+}
+
+func init() {
+	dnsExportAllCmd.Flags().StringVar(&exportAllDir, "dir", ".", "directory to write one export file per zone into")
+	dnsExportAllCmd.Flags().StringVar(&exportFormat, "format", "bind", "export format (bind, json, cfjson)")
+	dnsExportAllCmd.Flags().StringVar(&exportOrder, "order", "registrar", "record order (api, name, type, registrar)")
+	dnsExportAllCmd.Flags().IntVar(&exportAllConcurrency, "concurrency", 4, "number of zones to export in parallel")
+	addBulkErrorFlags(dnsExportAllCmd)
+	dnsCmd.AddCommand(dnsExportAllCmd)
+}
+
+// exportZone fetches and writes the records of one zone. It is called
+// concurrently and only shares the client, which is safe for concurrent use.
+func exportZone(ctx context.Context, c *client.Client, zone client.Zone) exportAllResult {
+	result := exportAllResult{Zone: zone.Name}
+
+	records, err := c.ListDNSRecords(ctx, zone.ID, "", "")
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Records = len(records)
+
+	path := filepath.Join(exportAllDir, zone.Name+exportExtension())
+
+	f, err := os.Create(path)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to create export file: %v", err)
+		return result
+	}
+	defer f.Close()
+
+	if err := writeExport(f, zone.Name, records); err != nil {
+		result.Error = fmt.Sprintf("failed to write export: %v", err)
+		return result
+	}
+	result.File = path
+	return result
+}
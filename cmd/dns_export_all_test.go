@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+func TestDNSExportAllConcurrent(t *testing.T) {
+	var zones []string
+	for i := 0; i < 12; i++ {
+		zones = append(zones, fmt.Sprintf("zone%d.example", i))
+	}
+	api := newMockAPI(t, zones...)
+	for i, zone := range zones {
+		for j := 0; j <= i%3; j++ {
+			api.addRecord(zone, cloudflare.DNSRecord{Type: "A", Name: fmt.Sprintf("host%d", j), Content: fmt.Sprintf("192.0.2.%d", j+1)})
+		}
+	}
+	dir := t.TempDir()
+
+	stdout, _, err := runCmd(t, api, "dns", "export-all", "--dir", dir, "--concurrency", "4", "--format", "json", "-o", "json")
+	if err != nil {
+		t.Fatalf("export-all failed: %v", err)
+	}
+
+	var results []exportAllResult
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if len(results) != len(zones) {
+		t.Fatalf("got %d results, want %d", len(results), len(zones))
+	}
+	for i, r := range results {
+		if r.Zone != zones[i] {
+			t.Errorf("result %d is for %s, want %s (results must keep the zone order)", i, r.Zone, zones[i])
+		}
+		if r.Error != "" {
+			t.Errorf("%s: %s", r.Zone, r.Error)
+			continue
+		}
+		if want := i%3 + 1; r.Records != want {
+			t.Errorf("%s: %d records, want %d", r.Zone, r.Records, want)
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, zones[i]+".json"))
+		if err != nil {
+			t.Errorf("%s: %v", r.Zone, err)
+			continue
+		}
+		// Every file must hold its own zone's records only
+		if strings.Count(string(data), zones[i]) < r.Records {
+			t.Errorf("%s: export does not contain the zone's records:\n%s", r.Zone, data)
+		}
+		for j, other := range zones {
+			if j != i && strings.Contains(string(data), `"`+other) {
+				t.Errorf("%s: export contains records of %s", r.Zone, other)
+			}
+		}
+	}
+}
+
+func TestDNSExportAllReportsFailures(t *testing.T) {
+	api := newMockAPI(t, "ok.example", "broken.example")
+	api.addRecord("ok.example", cloudflare.DNSRecord{Type: "A", Name: "www", Content: "192.0.2.1"})
+	dir := t.TempDir()
+	// A directory in place of the export file makes that zone's write fail
+	if err := os.Mkdir(filepath.Join(dir, "broken.example.zone"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, _, err := runCmd(t, api, "dns", "export-all", "--dir", dir, "-o", "json")
+	if err == nil {
+		t.Fatal("export-all succeeded, want an error for the failed zone")
+	}
+
+	var results []exportAllResult
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if len(results) != 2 || results[0].Error != "" || results[1].Error == "" {
+		t.Errorf("results = %+v, want ok.example exported and broken.example failed", results)
+	}
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/coollabsio/cloudflare-cli/internal/output"
+)
+
+// exportManifestName is the name of the manifest written by dns export --split-by-type
+const exportManifestName = "manifest.json"
+
+// exportManifest lists the files written by dns export --dir
+type exportManifest struct {
+	Zone    string               `json:"zone"`
+	Format  string               `json:"format"`
+	Records int                  `json:"records"`
+	Files   []exportManifestFile `json:"files"`
+}
+
+// exportManifestFile is one file of an export manifest
+type exportManifestFile struct {
+	Type    string `json:"type,omitempty"`
+	File    string `json:"file"`
+	Records int    `json:"records"`
+}
+
+// writeExportDir writes the export into --dir, as a single file or, with
+// --split-by-type, one file per record type plus a manifest
+func writeExportDir(zoneName string, records []client.DNSRecord) error {
+	if err := os.MkdirAll(exportDir, 0755); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	manifest := exportManifest{Zone: zoneName, Format: exportFormat, Records: len(records), Files: []exportManifestFile{}}
+	if !exportSplit {
+		name := zoneName + exportExtension()
+		if err := writeExportFile(filepath.Join(exportDir, name), zoneName, records); err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, exportManifestFile{File: name, Records: len(records)})
+	} else {
+		byType := make(map[string][]client.DNSRecord)
+		for _, r := range records {
+			t := strings.ToUpper(r.Type)
+			byType[t] = append(byType[t], r)
+		}
+		types := make([]string, 0, len(byType))
+		for t := range byType {
+			types = append(types, t)
+		}
+		slices.SortFunc(types, func(a, b string) int {
+			return cmp.Or(cmp.Compare(registrarTypeRank(a), registrarTypeRank(b)), cmp.Compare(a, b))
+		})
+
+		for _, t := range types {
+			name := t + exportExtension()
+			if err := writeExportFile(filepath.Join(exportDir, name), zoneName, byType[t]); err != nil {
+				return err
+			}
+			manifest.Files = append(manifest.Files, exportManifestFile{Type: t, File: name, Records: len(byType[t])})
+		}
+
+		f, err := os.Create(filepath.Join(exportDir, exportManifestName))
+		if err != nil {
+			return fmt.Errorf("failed to create manifest: %w", err)
+		}
+		defer f.Close()
+		if err := output.NewJSONEncoder(f, false).Encode(manifest); err != nil {
+			return fmt.Errorf("failed to write manifest: %w", err)
+		}
+	}
+
+	if outputFormat == "json" {
+		return out.WriteJSON(manifest)
+	}
+	out.TeeJSON(manifest)
+	headers := []string{"Type", "Records", "File"}
+	var rows [][]string
+	for _, f := range manifest.Files {
+		rows = append(rows, []string{f.Type, output.FormatInt(f.Records), filepath.Join(exportDir, f.File)})
+	}
+	if err := out.WriteTable(headers, rows); err != nil {
+		return err
+	}
+	out.WriteNote(fmt.Sprintf("\nExported %d record(s) to %d file(s) in %s", len(records), len(manifest.Files), exportDir))
+	return nil
+}
+
+// writeExportFile writes records to path in the selected export format
+func writeExportFile(path, zoneName string, records []client.DNSRecord) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer f.Close()
+	if err := writeExport(f, zoneName, records); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/coollabsio/cloudflare-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// dnsRecordIDFile is the --record-id-file of dns get and dns delete
+var dnsRecordIDFile string
+
+// recordIDArgs accepts <zone> <record-id>, or just <zone> with --record-id-file
+func recordIDArgs(cmd *cobra.Command, args []string) error {
+	if dnsRecordIDFile != "" {
+		if len(args) != 1 {
+			return fmt.Errorf("with --record-id-file, give only the zone")
+		}
+		return nil
+	}
+	return cobra.ExactArgs(2)(cmd, args)
+}
+
+// readRecordIDFile reads record IDs, one per line, ignoring blank lines, #
+// comments, and repeated IDs
+func readRecordIDFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read record ID file: %w", err)
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || seen[line] {
+			continue
+		}
+		seen[line] = true
+		ids = append(ids, line)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("record ID file %s does not list any IDs", path)
+	}
+	return ids, nil
+}
+
+// recordGetResult is one record of a dns get --record-id-file. Records that
+// could not be fetched only carry the requested ID and the error.
+type recordGetResult struct {
+	*client.DNSRecord
+	Error string `json:",omitempty"`
+}
+
+// getRecordsByID fetches the records listed in --record-id-file, reporting
+// failures per record
+func getRecordsByID(ctx context.Context, c *client.Client, zoneID string, ids []string) error {
+	results := make([]recordGetResult, len(ids))
+	failed := 0
+	for i, id := range ids {
+		if stopAfterFailure(failed) {
+			results[i] = recordGetResult{DNSRecord: &client.DNSRecord{ID: id}, Error: skippedAfterFailure}
+			failed++
+			continue
+		}
+		record, err := c.GetDNSRecord(ctx, zoneID, id)
+		if err != nil {
+			results[i] = recordGetResult{DNSRecord: &client.DNSRecord{ID: id}, Error: err.Error()}
+			failed++
+			continue
+		}
+		results[i] = recordGetResult{DNSRecord: record}
+	}
+
+	if outputFormat == "json" {
+		if err := out.WriteJSON(onlyFailed(results, func(r recordGetResult) bool { return r.Error != "" })); err != nil {
+			return err
+		}
+	} else if out.IsTemplate() {
+		if err := out.WriteTemplate(results); err != nil {
+			return err
+		}
+	} else {
+		out.TeeJSON(onlyFailed(results, func(r recordGetResult) bool { return r.Error != "" }))
+		headers := []string{"ID", "Type", "Name", "Content", "TTL", "Proxied", "Error"}
+		var rows [][]string
+		for _, r := range results {
+			if r.Error != "" {
+				rows = append(rows, []string{r.ID, "", "", "", "", "", r.Error})
+				continue
+			}
+			rows = append(rows, []string{r.ID, r.Type, r.Name, r.Content, output.FormatTTL(r.TTL), output.FormatBool(r.Proxied), ""})
+		}
+		if err := writeBulkResults(headers, rows); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d record(s) could not be fetched", failed, len(ids))
+	}
+	return nil
+}
+
+// deleteRecordsByID deletes the records listed in --record-id-file and
+// reports a per-record summary
+func deleteRecordsByID(ctx context.Context, c *client.Client, zoneID string, ids []string) error {
+	if !dnsYes && !c.DryRun() && !confirm(fmt.Sprintf("Delete %d DNS record(s) listed in %s?", len(ids), dnsRecordIDFile)) {
+		return fmt.Errorf("aborted: no records were deleted (use --yes to skip confirmation)")
+	}
+	if err := confirmLargeDelete(len(ids), c.DryRun()); err != nil {
+		return err
+	}
+
+	headers := []string{"Result", "ID", "Error"}
+	var rows [][]string
+	deleted, failed := 0, 0
+	for _, id := range ids {
+		var err error
+		if stopAfterFailure(failed) {
+			err = errors.New(skippedAfterFailure)
+		} else {
+			err = c.DeleteDNSRecord(ctx, zoneID, id)
+		}
+		switch {
+		case err != nil:
+			failed++
+			rows = append(rows, []string{"failed", id, err.Error()})
+		case c.DryRun():
+			deleted++
+			rows = append(rows, []string{"would delete", id, ""})
+		default:
+			deleted++
+			rows = append(rows, []string{"deleted", id, ""})
+		}
+	}
+
+	if err := writeBulkResults(headers, rows); err != nil {
+		return err
+	}
+	prefix := ""
+	if c.DryRun() {
+		prefix = "(dry-run) "
+	}
+	out.WriteNote(fmt.Sprintf("\n%s%d of %d record(s) deleted, %d failed", prefix, deleted, len(ids), failed))
+	if failed > 0 {
+		return fmt.Errorf("%d of %d record(s) failed to delete", failed, len(ids))
+	}
+	return nil
+}
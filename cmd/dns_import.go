@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/coollabsio/cloudflare-cli/internal/zonefile"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importAXFR          string
+	importFormat        string
+	importIncludeApexNS bool
+	importOverrideTTL   int
+	importProxyAll      bool
+	importProxyNone     bool
+	importResume        bool
+	importStateFile     string
+	importValidateOnly  bool
+)
+
+var dnsImportCmd = &cobra.Command{
+	Use:   "import <zone> [file]",
+	Short: "Import DNS records from a zone file or zone transfer",
+	Long: `Import DNS records into a zone from a BIND zone file, or pull them from
+another nameserver with a zone transfer (AXFR).
+
+SOA records are always skipped. NS records at the zone apex are skipped unless
+--include-apex-ns is given, since Cloudflare assigns its own nameservers.
+Use --dry-run to see what would be created.
+
+--format cfjson imports a backup written by 'dns export --format cfjson',
+restoring proxy status, comments, tags, priorities, and structured data along
+with the records. Its names are relative, so it can be imported into another
+zone.
+
+TTL and proxy settings from the source can be overridden for every record with
+--override-ttl and --proxy-all / --proxy-none. --proxy-all only proxies types
+that Cloudflare can proxy (A, AAAA, CNAME); other records are left unproxied.
+
+Examples:
+  cf dns import example.com example.com.zone
+  cf dns import example.com --axfr ns1.old-host.com
+  cf dns import example.com --axfr ns1.old-host.com --dry-run
+  cf dns import example.com example.com.zone --override-ttl auto --proxy-all
+  cf dns import example.com example.com.zone --validate-only
+  cf dns import example.org example.com.cf.json --format cfjson
+
+--validate-only parses the file and checks every record against Cloudflare's
+rules (supported types, TTL range, proxiable types, record content, CNAMEs
+sharing a name) without calling the API, so no credentials are needed. The
+zone must be given by name. Every record is reported as valid or invalid, and
+the command exits non-zero if any is invalid.
+
+Progress is written to a state file (--state-file, by default
+.cf-import-<zone>.state.json) as records are created, and removed once the
+import completes. If an import is interrupted (Ctrl-C, failures), run it again
+with --resume to skip the records already created. --resume also skips records
+identical to ones already in the zone, so nothing is duplicated even without
+a state file:
+  cf dns import example.com example.com.zone --resume`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if (len(args) == 2) == (importAXFR != "") {
+			return fmt.Errorf("provide either a zone file or --axfr <nameserver>")
+		}
+		if importProxyAll && importProxyNone {
+			return fmt.Errorf("--proxy-all and --proxy-none cannot be used together")
+		}
+		if importFormat != "bind" && importFormat != "cfjson" {
+			return fmt.Errorf("invalid --format: %s (must be 'bind' or 'cfjson')", importFormat)
+		}
+		if importFormat == "cfjson" && (importAXFR != "" || importValidateOnly) {
+			return fmt.Errorf("--format cfjson cannot be used with --axfr or --validate-only")
+		}
+		if importValidateOnly {
+			if importAXFR != "" {
+				return fmt.Errorf("--validate-only checks a zone file and cannot be used with --axfr")
+			}
+			return validateImportFile(cmd, strings.TrimSuffix(args[0], "."), args[1])
+		}
+
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		zone, err := resolveZoneDetails(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		opts := zonefile.Options{IncludeApexNS: importIncludeApexNS}
+
+		var records []client.CreateDNSRecordParams
+		if importAXFR != "" {
+			records, err = zonefile.Transfer(importAXFR, zone.Name, opts)
+		} else {
+			var f *os.File
+			f, err = os.Open(args[1])
+			if err != nil {
+				return fmt.Errorf("failed to open zone file: %w", err)
+			}
+			defer f.Close()
+			if importFormat == "cfjson" {
+				records, err = readCFJSON(f, zone.Name)
+			} else {
+				records, err = zonefile.Parse(f, zone.Name, opts)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(records) == 0 {
+			out.WriteSuccess("No records to import")
+			return nil
+		}
+
+		applyImportOverrides(cmd, records)
+
+		statePath := importStateFile
+		if statePath == "" {
+			statePath = defaultImportStatePath(zone.Name)
+		}
+		state := &importState{Zone: zone.Name, path: statePath, done: make(map[string]bool)}
+		if _, err := os.Stat(statePath); err == nil && !importResume {
+			fmt.Fprintf(os.Stderr, "Warning: %s is left over from an earlier import; use --resume to continue it instead of starting over\n", statePath)
+		}
+		if importResume {
+			state, err = loadImportState(statePath, zone.Name)
+			if err != nil {
+				return err
+			}
+			records, err = skipImportedRecords(ctx, c, zone.ID, state, records)
+			if err != nil {
+				return err
+			}
+			if len(records) == 0 {
+				out.WriteSuccess("All records have already been imported")
+				if c.DryRun() {
+					return nil
+				}
+				return state.Remove()
+			}
+		}
+
+		// Stop cleanly on Ctrl-C so the state file reflects what was created
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		err = createRecords(ctx, c, zone.ID, records, func(params client.CreateDNSRecordParams) {
+			if c.DryRun() {
+				return
+			}
+			if err := state.MarkCreated(params); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		})
+		if err != nil {
+			if !c.DryRun() {
+				fmt.Fprintf(os.Stderr, "Progress saved to %s; run again with --resume to continue.\n", statePath)
+			}
+			return err
+		}
+		if c.DryRun() {
+			return nil
+		}
+		return state.Remove()
+	},
+}
+
+// applyImportOverrides applies --override-ttl and --proxy-all/--proxy-none on
+// top of what the source specified
+func applyImportOverrides(cmd *cobra.Command, records []client.CreateDNSRecordParams) {
+	for i := range records {
+		if cmd.Flags().Changed("override-ttl") {
+			records[i].TTL = importOverrideTTL
+		}
+		if importProxyNone {
+			records[i].Proxied = false
+		}
+		if importProxyAll && isProxiableType(records[i].Type) {
+			records[i].Proxied = true
+		}
+	}
+}
+
+// skipImportedRecords drops the records recorded in the state file and those
+// identical to a record already in the zone
+func skipImportedRecords(ctx context.Context, c *client.Client, zoneID string, state *importState, records []client.CreateDNSRecordParams) ([]client.CreateDNSRecordParams, error) {
+	existing, err := c.ListDNSRecords(ctx, zoneID, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var remaining []client.CreateDNSRecordParams
+	skipped := 0
+	for _, params := range records {
+		present := slices.ContainsFunc(existing, func(r client.DNSRecord) bool {
+			return strings.EqualFold(r.Type, params.Type) && strings.EqualFold(r.Name, params.Name) && sameContent(r.Type, r.Content, params.Content)
+		})
+		if state.Has(params) || present {
+			skipped++
+			continue
+		}
+		remaining = append(remaining, params)
+	}
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Skipping %d record(s) already imported or present in the zone\n", skipped)
+	}
+	return remaining, nil
+}
+
+func init() {
+	dnsImportCmd.Flags().StringVar(&importAXFR, "axfr", "", "pull records via zone transfer from this nameserver (host or host:port)")
+	dnsImportCmd.Flags().StringVar(&importFormat, "format", "bind", "format of the import file (bind, cfjson)")
+	dnsImportCmd.Flags().BoolVar(&importIncludeApexNS, "include-apex-ns", false, "also import NS records at the zone apex")
+	importOverrideTTL = 1
+	dnsImportCmd.Flags().Var((*ttlValue)(&importOverrideTTL), "override-ttl", "set this TTL on every imported record (seconds, or 'auto')")
+	dnsImportCmd.Flags().BoolVar(&importProxyAll, "proxy-all", false, "proxy every imported record that can be proxied")
+	dnsImportCmd.Flags().BoolVar(&importProxyNone, "proxy-none", false, "import every record unproxied")
+	addBulkErrorFlags(dnsImportCmd)
+	dnsImportCmd.Flags().BoolVar(&importResume, "resume", false, "skip records created by an earlier run or already present in the zone")
+	dnsImportCmd.Flags().BoolVar(&importValidateOnly, "validate-only", false, "only check the zone file against Cloudflare's rules; no API access or credentials needed")
+	dnsImportCmd.Flags().StringVar(&importStateFile, "state-file", "", "file that tracks created records (default .cf-import-<zone>.state.json)")
+	dnsCmd.AddCommand(dnsImportCmd)
+}
+
+// createRecords creates the records, reporting the result of every create and
+// returning an error if any of them failed. Large sets are sent through the
+// batch endpoint; smaller ones are created one at a time. onCreated is called
+// for each record as soon as it has been created. If ctx is cancelled, the
+// remaining records (or batches) are skipped.
+func createRecords(ctx context.Context, c *client.Client, zoneID string, records []client.CreateDNSRecordParams, onCreated func(client.CreateDNSRecordParams)) error {
+	headers := []string{"Result", "ID", "Type", "Name", "Content", "Error"}
+	var rows [][]string
+
+	failed := 0
+	if len(records) > client.BatchThreshold {
+		result, err := c.BatchDNSRecords(ctx, zoneID, client.BatchDNSRecordsParams{Posts: records})
+		// Batches are applied whole, so the records not in the result all
+		// failed, or were skipped if ctx was cancelled before their batch
+		for i, params := range records {
+			switch {
+			case i >= len(result.Posts) && ctx.Err() != nil:
+				failed++
+				rows = append(rows, []string{"skipped", "", params.Type, params.Name, params.Content, "interrupted"})
+			case i >= len(result.Posts):
+				failed++
+				msg := "not in batch response"
+				if err != nil {
+					msg = err.Error()
+				}
+				rows = append(rows, []string{"failed", "", params.Type, params.Name, params.Content, msg})
+			case c.DryRun():
+				rows = append(rows, []string{"would create", "", params.Type, params.Name, params.Content, ""})
+			default:
+				record := result.Posts[i]
+				onCreated(params)
+				rows = append(rows, []string{"created", record.ID, record.Type, record.Name, record.Content, ""})
+			}
+		}
+	} else {
+		for _, params := range records {
+			if ctx.Err() != nil {
+				failed++
+				rows = append(rows, []string{"skipped", "", params.Type, params.Name, params.Content, "interrupted"})
+				continue
+			}
+			if stopAfterFailure(failed) {
+				failed++
+				rows = append(rows, []string{"skipped", "", params.Type, params.Name, params.Content, skippedAfterFailure})
+				continue
+			}
+			record, err := c.CreateDNSRecord(ctx, zoneID, params)
+			switch {
+			case err != nil:
+				failed++
+				rows = append(rows, []string{"failed", "", params.Type, params.Name, params.Content, err.Error()})
+			case c.DryRun():
+				rows = append(rows, []string{"would create", "", params.Type, params.Name, params.Content, ""})
+			default:
+				onCreated(params)
+				rows = append(rows, []string{"created", record.ID, record.Type, record.Name, record.Content, ""})
+			}
+		}
+	}
+
+	if err := writeBulkResults(headers, rows); err != nil {
+		return err
+	}
+
+	prefix := ""
+	if c.DryRun() {
+		prefix = "(dry-run) "
+	}
+	out.WriteNote(fmt.Sprintf("\n%s%d of %d record(s) imported, %d failed", prefix, len(records)-failed, len(records), failed))
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d record(s) failed to import", failed, len(records))
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+)
+
+// importState records which records of an import have been created, so an
+// interrupted import can be resumed without creating duplicates
+type importState struct {
+	Zone    string   `json:"zone"`
+	Created []string `json:"created"`
+
+	path string
+	done map[string]bool
+}
+
+// defaultImportStatePath returns the state file used when --state-file is not given
+func defaultImportStatePath(zoneName string) string {
+	return fmt.Sprintf(".cf-import-%s.state.json", zoneName)
+}
+
+// loadImportState reads the state file at path. A missing file yields an empty state.
+func loadImportState(path, zoneName string) (*importState, error) {
+	state := &importState{Zone: zoneName, path: path, done: make(map[string]bool)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse import state %s: %w", path, err)
+	}
+	if state.Zone != zoneName {
+		return nil, fmt.Errorf("import state %s belongs to zone %s, not %s", path, state.Zone, zoneName)
+	}
+	for _, key := range state.Created {
+		state.done[key] = true
+	}
+	return state, nil
+}
+
+// Has reports whether the record was created by an earlier run
+func (s *importState) Has(params client.CreateDNSRecordParams) bool {
+	return s.done[importKey(params.Type, params.Name, params.Content)]
+}
+
+// MarkCreated records a created record and saves the state file
+func (s *importState) MarkCreated(params client.CreateDNSRecordParams) error {
+	key := importKey(params.Type, params.Name, params.Content)
+	if s.done[key] {
+		return nil
+	}
+	s.done[key] = true
+	s.Created = append(s.Created, key)
+	return s.save()
+}
+
+// Remove deletes the state file once the import has completed
+func (s *importState) Remove() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *importState) save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write import state: %w", err)
+	}
+	return nil
+}
+
+// importKey identifies a record by type, name, and content
+func importKey(recordType, name, content string) string {
+	return strings.ToUpper(recordType) + " " + strings.ToLower(strings.TrimSuffix(name, ".")) + " " + content
+}
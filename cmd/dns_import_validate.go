@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/coollabsio/cloudflare-cli/internal/zonefile"
+	"github.com/spf13/cobra"
+)
+
+// cloudflareRecordTypes are the record types Cloudflare DNS accepts
+var cloudflareRecordTypes = []string{
+	"A", "AAAA", "CAA", "CERT", "CNAME", "DNSKEY", "DS", "HTTPS", "LOC", "MX", "NAPTR",
+	"NS", "OPENPGPKEY", "PTR", "SMIMEA", "SRV", "SSHFP", "SVCB", "TLSA", "TXT", "URI",
+}
+
+// validateImportFile parses a zone file and checks every record against
+// Cloudflare's rules without calling the API. It prints a report and returns
+// an error if the file cannot be parsed or any record is invalid.
+func validateImportFile(cmd *cobra.Command, zoneName, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open zone file: %w", err)
+	}
+	defer f.Close()
+
+	entries, parseErr := zonefile.ParseAll(f, zoneName, zonefile.Options{IncludeApexNS: importIncludeApexNS})
+	records := make([]client.CreateDNSRecordParams, len(entries))
+	for i, e := range entries {
+		records[i] = e.Params
+	}
+	applyImportOverrides(cmd, records)
+
+	cmd.SilenceUsage = true
+	problems := cnameConflicts(records)
+	headers := []string{"Result", "Type", "Name", "Content", "Error"}
+	var rows [][]string
+	invalid := 0
+	for i, e := range entries {
+		err := e.Err
+		if err == nil {
+			err = validateImportRecord(records[i])
+		}
+		if err == nil {
+			err = problems[i]
+		}
+		if err != nil {
+			invalid++
+			rows = append(rows, []string{"invalid", records[i].Type, records[i].Name, records[i].Content, err.Error()})
+			continue
+		}
+		rows = append(rows, []string{"valid", records[i].Type, records[i].Name, records[i].Content, ""})
+	}
+
+	if err := writeBulkResults(headers, rows); err != nil {
+		return err
+	}
+	out.WriteNote(fmt.Sprintf("\n%d of %d record(s) valid, %d invalid", len(entries)-invalid, len(entries), invalid))
+
+	if parseErr != nil {
+		return parseErr
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d record(s) are invalid", invalid, len(entries))
+	}
+	return nil
+}
+
+// validateImportRecord checks a single record the way Cloudflare would on create
+func validateImportRecord(params client.CreateDNSRecordParams) error {
+	recordType := strings.ToUpper(params.Type)
+	if !slices.Contains(cloudflareRecordTypes, recordType) {
+		return fmt.Errorf("%s records are not supported by Cloudflare", recordType)
+	}
+	if params.TTL != 1 && (params.TTL < 60 || params.TTL > 86400) {
+		return fmt.Errorf("TTL %d is out of range (must be auto or between 60 and 86400 seconds)", params.TTL)
+	}
+	if params.Proxied && !isProxiableType(recordType) {
+		return fmt.Errorf("%s records cannot be proxied", recordType)
+	}
+	return validateRecordContent(recordType, params.Content, params.Proxied)
+}
+
+// cnameConflicts finds records that share a name with a CNAME, which Cloudflare
+// rejects. The result holds an error for each conflicting record, by index.
+func cnameConflicts(records []client.CreateDNSRecordParams) []error {
+	cnames := make(map[string]int)
+	for _, r := range records {
+		if strings.EqualFold(r.Type, "CNAME") {
+			cnames[strings.ToLower(r.Name)]++
+		}
+	}
+
+	problems := make([]error, len(records))
+	for i, r := range records {
+		count := cnames[strings.ToLower(r.Name)]
+		switch {
+		case count == 0:
+		case strings.EqualFold(r.Type, "CNAME") && count > 1:
+			problems[i] = fmt.Errorf("%s has %d CNAME records; a name can only have one CNAME", r.Name, count)
+		case !strings.EqualFold(r.Type, "CNAME"):
+			problems[i] = fmt.Errorf("%s also has a CNAME record; a CNAME cannot coexist with other records", r.Name)
+		}
+	}
+	return problems
+}
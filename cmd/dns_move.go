@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	moveFrom      string
+	moveTo        string
+	moveOverwrite bool
+)
+
+var dnsMoveCmd = &cobra.Command{
+	Use:   "move <zone>",
+	Short: "Move the records of one name to another name",
+	Long: `Rename every record under --from to --to within a zone, e.g. to move the
+records of www to the zone apex. Names are relative to the zone; "@" is the
+apex. Use --type to move only records of one type.
+
+Records are renamed in place, so IDs, content, TTLs, proxy status, comments,
+and tags are kept. The move is refused if --to already has records that
+would conflict (records of the same type, or a CNAME on either side) unless
+--overwrite is given, which deletes the conflicting records first.
+
+Asks for confirmation unless --yes is given. Deleting more than
+--confirm-threshold conflicting records (default 10) also asks you to type
+DELETE, even with --yes.
+
+Examples:
+  cf dns move example.com --from www --to @
+  cf dns move example.com --from old-api --to api --type CNAME
+  cf dns move example.com --from www --to @ --overwrite --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if moveFrom == "" || moveTo == "" {
+			return fmt.Errorf("--from and --to are required")
+		}
+
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		zone, err := resolveZoneDetails(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+		from := qualifyName(moveFrom, zone.Name)
+		to := qualifyName(moveTo, zone.Name)
+		if strings.EqualFold(from, to) {
+			return fmt.Errorf("--from and --to are the same name (%s)", from)
+		}
+
+		sources, err := c.FindDNSRecords(ctx, zone.ID, from, dnsType)
+		if err != nil {
+			return err
+		}
+		if len(sources) == 0 {
+			out.WriteSuccess(fmt.Sprintf("No DNS records found at %s", from))
+			return nil
+		}
+		existing, err := c.FindDNSRecords(ctx, zone.ID, to, "")
+		if err != nil {
+			return err
+		}
+		conflicts := moveConflicts(sources, existing)
+		if len(conflicts) > 0 && !moveOverwrite {
+			var described []string
+			for _, r := range conflicts {
+				described = append(described, fmt.Sprintf("%s %s (%s)", r.Type, r.Content, r.ID))
+			}
+			return fmt.Errorf("%s already has conflicting records: %s (use --overwrite to delete them)", to, strings.Join(described, ", "))
+		}
+
+		prompt := fmt.Sprintf("Move %d record(s) from %s to %s?", len(sources), from, to)
+		if len(conflicts) > 0 {
+			prompt = fmt.Sprintf("Delete %d record(s) at %s and move %d record(s) from %s?", len(conflicts), to, len(sources), from)
+		}
+		if !dnsYes && !c.DryRun() && !confirm(prompt) {
+			return fmt.Errorf("aborted: no records were moved (use --yes to skip confirmation)")
+		}
+		if err := confirmLargeDelete(len(conflicts), c.DryRun()); err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+		headers := []string{"Result", "ID", "Type", "Name", "Content", "Error"}
+		var rows [][]string
+		failed, moved := 0, 0
+		result := func(done string, r client.DNSRecord, name string, err error) {
+			switch {
+			case err != nil:
+				failed++
+				rows = append(rows, []string{"failed", r.ID, r.Type, name, r.Content, err.Error()})
+			case c.DryRun():
+				rows = append(rows, []string{"would " + done, r.ID, r.Type, name, r.Content, ""})
+			default:
+				rows = append(rows, []string{done, r.ID, r.Type, name, r.Content, ""})
+			}
+		}
+
+		for _, r := range conflicts {
+			if stopAfterFailure(failed) {
+				result("delete", r, r.Name, errors.New(skippedAfterFailure))
+				continue
+			}
+			result("delete", r, r.Name, c.DeleteDNSRecord(ctx, zone.ID, r.ID))
+		}
+		for _, r := range sources {
+			// Moving onto records that could not be deleted would leave a conflict
+			if stopAfterFailure(failed) || (failed > 0 && len(conflicts) > 0) {
+				result("move", r, to, errors.New(skippedAfterFailure))
+				continue
+			}
+			_, err := c.UpdateDNSRecord(ctx, zone.ID, r.ID, client.UpdateDNSRecordParams{
+				Type:    r.Type,
+				Name:    to,
+				Content: r.Content,
+				Tags:    r.Tags,
+				Data:    r.Data,
+			})
+			if err == nil {
+				moved++
+			}
+			result("move", r, to, err)
+		}
+
+		if err := writeBulkResults(headers, rows); err != nil {
+			return err
+		}
+		prefix := ""
+		if c.DryRun() {
+			prefix = "(dry-run) "
+		}
+		out.WriteNote(fmt.Sprintf("\n%s%d of %d record(s) moved from %s to %s, %d operation(s) failed", prefix, moved, len(sources), from, to, failed))
+		if failed > 0 {
+			return fmt.Errorf("%d operation(s) failed", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	dnsMoveCmd.Flags().StringVar(&moveFrom, "from", "", "name to move records from (relative to the zone, @ for the apex)")
+	dnsMoveCmd.Flags().StringVar(&moveTo, "to", "", "name to move records to (relative to the zone, @ for the apex)")
+	dnsMoveCmd.Flags().StringVarP(&dnsType, "type", "t", "", "only move records of this type")
+	dnsMoveCmd.Flags().BoolVar(&moveOverwrite, "overwrite", false, "delete conflicting records at the destination first")
+	dnsMoveCmd.Flags().BoolVarP(&dnsYes, "yes", "y", false, "skip the confirmation prompt")
+	addBulkErrorFlags(dnsMoveCmd)
+	addConfirmThresholdFlag(dnsMoveCmd)
+	dnsCmd.AddCommand(dnsMoveCmd)
+}
+
+// moveConflicts returns the existing destination records that the moved
+// records would clash with: the same type, or a CNAME on either side
+func moveConflicts(sources, existing []client.DNSRecord) []client.DNSRecord {
+	var conflicts []client.DNSRecord
+	for _, e := range existing {
+		for _, s := range sources {
+			if strings.EqualFold(e.Type, s.Type) || strings.EqualFold(e.Type, "CNAME") || strings.EqualFold(s.Type, "CNAME") {
+				conflicts = append(conflicts, e)
+				break
+			}
+		}
+	}
+	return conflicts
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	replaceOld string
+	replaceNew string
+)
+
+var dnsReplaceContentCmd = &cobra.Command{
+	Use:   "replace-content <zone>",
+	Short: "Rewrite every record with one content to another",
+	Long: `Update every DNS record whose content exactly matches --old to --new, e.g.
+to point all records at a new server's IP when migrating. Use --type to only
+change records of one type.
+
+Records are updated in place, so IDs, names, TTLs, proxy status, comments,
+and tags are kept.
+
+Asks for confirmation unless --yes is given. Use --dry-run to see which
+records would change.
+
+Examples:
+  cf dns replace-content example.com --old 192.0.2.1 --new 198.51.100.1
+  cf dns replace-content example.com --old 2001:db8::1 --new 2001:db8::2 --type AAAA --yes
+  cf dns replace-content example.com --old old-lb.example.net --new lb.example.net --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if replaceOld == "" || replaceNew == "" {
+			return fmt.Errorf("--old and --new are required")
+		}
+		if replaceOld == replaceNew {
+			return fmt.Errorf("--old and --new are the same (%s)", replaceOld)
+		}
+
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		zoneID, err := resolveZone(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		records, err := c.ListDNSRecords(ctx, zoneID, dnsType, "")
+		if err != nil {
+			return err
+		}
+		var matches []client.DNSRecord
+		for _, r := range records {
+			if r.Content == replaceOld {
+				matches = append(matches, r)
+			}
+		}
+		if len(matches) == 0 {
+			out.WriteSuccess(fmt.Sprintf("No DNS records found with content %s", replaceOld))
+			return nil
+		}
+
+		if !dnsYes && !c.DryRun() && !confirm(fmt.Sprintf("Change the content of %d record(s) from %s to %s?", len(matches), replaceOld, replaceNew)) {
+			return fmt.Errorf("aborted: no records were changed (use --yes to skip confirmation)")
+		}
+
+		cmd.SilenceUsage = true
+		headers := []string{"Result", "ID", "Type", "Name", "Content", "Error"}
+		var rows [][]string
+		updated, failed := 0, 0
+		for _, r := range matches {
+			var err error
+			if stopAfterFailure(failed) {
+				err = errors.New(skippedAfterFailure)
+			} else {
+				_, err = c.UpdateDNSRecord(ctx, zoneID, r.ID, client.UpdateDNSRecordParams{
+					Type:    r.Type,
+					Name:    r.Name,
+					Content: replaceNew,
+					Tags:    r.Tags,
+				})
+			}
+			switch {
+			case err != nil:
+				failed++
+				rows = append(rows, []string{"failed", r.ID, r.Type, r.Name, r.Content, err.Error()})
+			case c.DryRun():
+				updated++
+				rows = append(rows, []string{"would update", r.ID, r.Type, r.Name, replaceNew, ""})
+			default:
+				updated++
+				rows = append(rows, []string{"updated", r.ID, r.Type, r.Name, replaceNew, ""})
+			}
+		}
+
+		if err := writeBulkResults(headers, rows); err != nil {
+			return err
+		}
+		prefix := ""
+		if c.DryRun() {
+			prefix = "(dry-run) "
+		}
+		out.WriteNote(fmt.Sprintf("\n%s%d of %d record(s) changed from %s to %s, %d failed", prefix, updated, len(matches), replaceOld, replaceNew, failed))
+		if failed > 0 {
+			return fmt.Errorf("%d of %d record(s) failed to update", failed, len(matches))
+		}
+		return nil
+	},
+}
+
+func init() {
+	dnsReplaceContentCmd.Flags().StringVar(&replaceOld, "old", "", "content to replace (exact match, e.g. the old IP address)")
+	dnsReplaceContentCmd.Flags().StringVar(&replaceNew, "new", "", "content to write instead")
+	dnsReplaceContentCmd.Flags().StringVarP(&dnsType, "type", "t", "", "only change records of this type")
+	dnsReplaceContentCmd.Flags().BoolVarP(&dnsYes, "yes", "y", false, "skip the confirmation prompt")
+	addBulkErrorFlags(dnsReplaceContentCmd)
+	dnsCmd.AddCommand(dnsReplaceContentCmd)
+}
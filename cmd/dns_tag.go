@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var dnsTags []string
+
+var dnsTagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Add or remove tags on DNS records in bulk",
+}
+
+var dnsTagAddCmd = &cobra.Command{
+	Use:   "add <zone>",
+	Short: "Add tags to matching DNS records",
+	Long: `Add one or more tags to every DNS record matching the filters.
+Existing tags and all other record fields are preserved.
+
+Examples:
+  cf dns tag add example.com --name-contains staging --tag env:staging
+  cf dns tag add example.com --type A --tag team:web --tag tier:frontend --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTagChange(args[0], func(tags []string) []string {
+			for _, t := range dnsTags {
+				if !slices.Contains(tags, t) {
+					tags = append(tags, t)
+				}
+			}
+			return tags
+		})
+	},
+}
+
+var dnsTagRemoveCmd = &cobra.Command{
+	Use:   "remove <zone>",
+	Short: "Remove tags from matching DNS records",
+	Long: `Remove one or more tags from every DNS record matching the filters.
+Other tags and all other record fields are preserved.
+
+Examples:
+  cf dns tag remove example.com --name-contains staging --tag env:staging`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTagChange(args[0], func(tags []string) []string {
+			var kept []string
+			for _, t := range tags {
+				if !slices.Contains(dnsTags, t) {
+					kept = append(kept, t)
+				}
+			}
+			return kept
+		})
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{dnsTagAddCmd, dnsTagRemoveCmd} {
+		addDNSFilterFlags(cmd)
+		addBulkErrorFlags(cmd)
+		cmd.Flags().StringArrayVar(&dnsTags, "tag", nil, "tag to add or remove, e.g. env:staging (repeatable)")
+		dnsTagCmd.AddCommand(cmd)
+	}
+	dnsCmd.AddCommand(dnsTagCmd)
+}
+
+// runTagChange applies change to the tags of every record matching the filters
+// and reports a per-record summary
+func runTagChange(zoneArg string, change func(tags []string) []string) error {
+	if len(dnsTags) == 0 {
+		return fmt.Errorf("at least one --tag is required")
+	}
+
+	c, err := client.New(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	zone, err := resolveZoneDetails(c, ctx, zoneArg)
+	if err != nil {
+		return err
+	}
+	zoneID := zone.ID
+
+	records, err := c.ListDNSRecords(ctx, zoneID, dnsType, recordName(zone.Name))
+	if err != nil {
+		return err
+	}
+	records, err = filterDNSRecords(records, zone.Name)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		out.WriteSuccess("No matching DNS records found")
+		return nil
+	}
+
+	headers := []string{"Result", "ID", "Type", "Name", "Tags", "Error"}
+	var rows [][]string
+	updated, unchanged, failed := 0, 0, 0
+
+	var pending []client.DNSRecord
+	var pendingTags [][]string
+	for _, r := range records {
+		newTags := change(slices.Clone(r.Tags))
+		if slices.Equal(newTags, r.Tags) {
+			unchanged++
+			rows = append(rows, []string{"unchanged", r.ID, r.Type, r.Name, strings.Join(r.Tags, ","), ""})
+			continue
+		}
+		pending = append(pending, r)
+		pendingTags = append(pendingTags, newTags)
+	}
+
+	// errs[i] is the result of updating pending[i]
+	errs := make([]error, len(pending))
+	if len(pending) > client.BatchThreshold {
+		var patches []client.BatchPatch
+		for i, r := range pending {
+			patches = append(patches, client.BatchPatch{ID: r.ID, Params: client.UpdateDNSRecordParams{Tags: pendingTags[i]}})
+		}
+		result, err := c.BatchDNSRecords(ctx, zoneID, client.BatchDNSRecordsParams{Patches: patches})
+		for i := len(result.Patches); i < len(pending); i++ {
+			errs[i] = err
+		}
+	} else {
+		failures := 0
+		for i, r := range pending {
+			if stopAfterFailure(failures) {
+				errs[i] = errors.New(skippedAfterFailure)
+				continue
+			}
+			_, errs[i] = c.UpdateDNSRecord(ctx, zoneID, r.ID, client.UpdateDNSRecordParams{
+				Type:    r.Type,
+				Name:    r.Name,
+				Content: r.Content,
+				Tags:    pendingTags[i],
+			})
+			if errs[i] != nil {
+				failures++
+			}
+		}
+	}
+
+	for i, r := range pending {
+		switch {
+		case errs[i] != nil:
+			failed++
+			rows = append(rows, []string{"failed", r.ID, r.Type, r.Name, strings.Join(r.Tags, ","), errs[i].Error()})
+		case c.DryRun():
+			updated++
+			rows = append(rows, []string{"would update", r.ID, r.Type, r.Name, strings.Join(pendingTags[i], ","), ""})
+		default:
+			updated++
+			rows = append(rows, []string{"updated", r.ID, r.Type, r.Name, strings.Join(pendingTags[i], ","), ""})
+		}
+	}
+
+	if err := writeBulkResults(headers, rows); err != nil {
+		return err
+	}
+
+	prefix := ""
+	if c.DryRun() {
+		prefix = "(dry-run) "
+	}
+	out.WriteNote(fmt.Sprintf("\n%s%d updated, %d unchanged, %d failed", prefix, updated, unchanged, failed))
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d record(s) failed to update", failed, len(records))
+	}
+	return nil
+}
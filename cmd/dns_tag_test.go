@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+func TestDNSTagAddSummary(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "www", Content: "192.0.2.1", Tags: []string{"team:web"}})
+
+	stdout, _, err := runCmd(t, api, "dns", "tag", "add", "example.com", "--name", "www", "--tag", "env:prod")
+	if err != nil || !strings.Contains(stdout, "1 updated, 0 unchanged, 0 failed") {
+		t.Errorf("table output: %v, want the summary:\n%s", err, stdout)
+	}
+	if tags := api.zoneRecords("example.com")[0].Tags; !slices.Equal(tags, []string{"team:web", "env:prod"}) {
+		t.Errorf("tags = %v, want the existing tag kept and env:prod added", tags)
+	}
+
+	tee := filepath.Join(t.TempDir(), "tee.json")
+	stdout, _, err = runCmd(t, api, "dns", "tag", "add", "example.com", "--name", "www", "--tag", "tier:frontend", "-o", "env", "--tee", tee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		if !strings.HasPrefix(line, "CF_") {
+			t.Errorf("env output has a line that is not an assignment: %q", line)
+		}
+	}
+	if data, _ := os.ReadFile(tee); !strings.Contains(string(data), "1 updated, 0 unchanged, 0 failed") {
+		t.Errorf("tee does not have the summary:\n%s", data)
+	}
+}
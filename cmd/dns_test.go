@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+)
+
+func TestSameContent(t *testing.T) {
+	const dkimKey = "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAu5Jz2xK1vQvL7yXw3E4aQ9mN8sR2tP6kL1jH0gF5dS3aZ7xC9vB2nM4qW8eR6tY1uI0oP3lK5jH7gF2dS9aZ4xC6vB1nM8qW3eR7tY2uI9oP4lK6jH1gF8dS5aZ3xC2vB7nM9qW4eR1tY6uI3oP8lK2jH5gF9dS4aZ6xC1vB3nM7qW2eR5tY8uI4oP9lK1jH3gF6dS7aZ8xC5vB4nM2qW9eR3tY7uI1oP5lK8jH2gF4dS6aZ9xC3vB8nM1qW7eR2tY5uI6oP1lK3jH9gF7dS2aZ5xC8vB6nM3qW1eR4tY9uI2oP7lK4jH6gF1dS8aZ2xC7vB5nM6qIDAQAB"
+
+	tests := []struct {
+		name       string
+		recordType string
+		a, b       string
+		want       bool
+	}{
+		{
+			"DKIM as one string and as split strings", "TXT",
+			`"v=DKIM1; k=rsa; p=` + dkimKey + `"`,
+			`"v=DKIM1; k=rsa; p=` + dkimKey[:200] + `" "` + dkimKey[200:] + `"`,
+			true,
+		},
+		{
+			"DKIM unquoted and as split strings", "TXT",
+			"v=DKIM1; k=rsa; p=" + dkimKey,
+			`"v=DKIM1; k=rsa; p=` + dkimKey[:100] + `" "` + dkimKey[100:250] + `" "` + dkimKey[250:] + `"`,
+			true,
+		},
+		{
+			"DKIM with a different key", "TXT",
+			`"v=DKIM1; k=rsa; p=` + dkimKey[:200] + `" "` + dkimKey[200:] + `"`,
+			`"v=DKIM1; k=rsa; p=` + dkimKey[:200] + `" "` + dkimKey[201:] + `"`,
+			false,
+		},
+		{"escaped quote", "TXT", `"say \"hi\""`, `say "hi"`, true},
+		{"TXT is case-sensitive", "TXT", "v=spf1 -all", "V=SPF1 -all", false},
+		{"hostname case and trailing dot", "CNAME", "Target.Example.com.", "target.example.com", true},
+		{"MX target", "MX", "mail.example.com.", "mail.example.com", true},
+		{"address", "A", "192.0.2.1", "192.0.2.2", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameContent(tt.recordType, tt.a, tt.b); got != tt.want {
+				t.Errorf("sameContent(%s, %q, %q) = %v, want %v", tt.recordType, tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDNSCreateNSDelegation(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+
+	stdout, stderr, err := runCmd(t, api, "dns", "create", "example.com", "--name", "dev", "--type", "NS",
+		"--content", "ns1.other-dns.com", "--content", "ns2.other-dns.com", "-o", "json")
+	if err != nil {
+		t.Fatalf("create failed: %v\n%s", err, stderr)
+	}
+
+	var created []client.DNSRecord
+	if err := json.Unmarshal([]byte(stdout), &created); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	records := api.zoneRecords("example.com")
+	if len(created) != 2 || len(records) != 2 {
+		t.Fatalf("created %d records (%d stored), want 2", len(created), len(records))
+	}
+	for i, want := range []string{"ns1.other-dns.com", "ns2.other-dns.com"} {
+		r := records[i]
+		if r.Type != "NS" || r.Name != "dev.example.com" || r.Content != want || *r.Proxied {
+			t.Errorf("record %d = %s %s %s (proxied %v), want NS dev.example.com %s", i, r.Type, r.Name, r.Content, *r.Proxied, want)
+		}
+	}
+}
+
+func TestDNSCreateNSValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"proxied", []string{"--type", "NS", "--content", "ns1.other-dns.com", "--proxied"}, "NS records cannot be proxied"},
+		{"address content", []string{"--type", "NS", "--content", "192.0.2.1 ns2.other-dns.com"}, "expected a single nameserver hostname"},
+		{"repeated content for another type", []string{"--type", "A", "--content", "192.0.2.1", "--content", "192.0.2.2"}, "only supported for NS records"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newMockAPI(t, "example.com")
+			_, _, err := runCmd(t, api, append([]string{"dns", "create", "example.com", "--name", "dev"}, tt.args...)...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			if len(api.requests) > 0 {
+				t.Errorf("requests sent despite the invalid record: %v", api.requests)
+			}
+		})
+	}
+}
+
+func TestDNSApexCreateAndFind(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "www", Content: "192.0.2.9"})
+
+	if _, stderr, err := runCmd(t, api, "dns", "create", "example.com", "--name", "@", "--type", "A", "--content", "192.0.2.1"); err != nil {
+		t.Fatalf("create --name @ failed: %v\n%s", err, stderr)
+	}
+	if _, stderr, err := runCmd(t, api, "dns", "create", "example.com", "--apex", "--type", "A", "--content", "192.0.2.2"); err != nil {
+		t.Fatalf("create --apex failed: %v\n%s", err, stderr)
+	}
+
+	for _, args := range [][]string{
+		{"dns", "find", "example.com", "--name", "@", "--type", "A"},
+		{"dns", "find", "example.com", "--apex", "--type", "A"},
+		{"dns", "find", "example.com", "--name", "example.com", "--type", "A"},
+	} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			stdout, stderr, err := runCmd(t, api, append(args, "-o", "json")...)
+			if err != nil {
+				t.Fatalf("%v\n%s", err, stderr)
+			}
+			var records []client.DNSRecord
+			if err := json.Unmarshal([]byte(stdout), &records); err != nil {
+				t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+			}
+			if len(records) != 2 {
+				t.Fatalf("found %d records, want the 2 apex records:\n%s", len(records), stdout)
+			}
+			for _, r := range records {
+				if r.Name != "example.com" {
+					t.Errorf("found %s, want only example.com", r.Name)
+				}
+			}
+		})
+	}
+
+	stdout, _, err := runCmd(t, api, "dns", "list", "example.com", "--name", "@")
+	if err != nil || strings.Count(stdout, "192.0.2.") != 2 || strings.Contains(stdout, "www") {
+		t.Errorf("list --name @: %v, want the 2 apex records only:\n%s", err, stdout)
+	}
+	if _, _, err := runCmd(t, api, "dns", "exists", "example.com", "--apex", "--type", "A", "--content", "192.0.2.2", "--quiet"); err != nil {
+		t.Errorf("exists --apex: %v, want the record to exist", err)
+	}
+	if _, _, err := runCmd(t, api, "dns", "find", "example.com", "--name", "@", "--apex"); err == nil {
+		t.Error("find with --name and --apex succeeded, want an error")
+	}
+}
+
+func TestDNSApexCNAMEFlattening(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "MX", Name: "@", Content: "mail.example.com", Priority: cloudflare.Uint16Ptr(10)})
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "TXT", Name: "@", Content: "v=spf1 -all"})
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "CNAME", Name: "www", Content: "example.com"})
+
+	_, stderr, err := runCmd(t, api, "dns", "create", "example.com", "--apex", "--type", "CNAME", "--content", "app.example.net")
+	if err != nil {
+		t.Fatalf("apex CNAME next to MX and TXT was refused: %v\n%s", err, stderr)
+	}
+	if !strings.Contains(stderr, "flattened by Cloudflare") || !strings.Contains(stderr, "app.example.net") {
+		t.Errorf("no flattening note on stderr:\n%s", stderr)
+	}
+	if n := len(api.zoneRecords("example.com")); n != 4 {
+		t.Errorf("%d records stored, want 4", n)
+	}
+
+	stdout, _, err := runCmd(t, api, "dns", "list", "example.com", "--type", "CNAME")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout, "CNAME (flattened)") {
+		t.Errorf("dns list does not mark the apex CNAME:\n%s", stdout)
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		switch {
+		case strings.Contains(line, "app.example.net") && !strings.Contains(line, "CNAME (flattened)"):
+			t.Errorf("apex CNAME not marked as flattened: %q", line)
+		case strings.Contains(line, "www.example.com") && strings.Contains(line, "flattened"):
+			t.Errorf("CNAME below the apex marked as flattened: %q", line)
+		}
+	}
+
+	// A subdomain CNAME gets no note
+	_, stderr, err = runCmd(t, api, "dns", "create", "example.com", "--name", "blog", "--type", "CNAME", "--content", "app.example.net")
+	if err != nil || strings.Contains(stderr, "flattened") {
+		t.Errorf("blog CNAME: error %v, stderr %q; want success without a flattening note", err, stderr)
+	}
+
+	// Flattening doesn't let a CNAME share the apex with an address record
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "@", Content: "192.0.2.1"})
+	if _, _, err := runCmd(t, api, "dns", "create", "example.com", "--apex", "--type", "A", "--content", "192.0.2.2"); err == nil || !strings.Contains(err.Error(), "a CNAME already exists") {
+		t.Errorf("apex A next to the apex CNAME: error = %v, want a CNAME conflict", err)
+	}
+}
+
+func TestDNSListPageFooter(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+	for _, name := range []string{"a", "b", "c"} {
+		api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: name, Content: "192.0.2.1"})
+	}
+
+	stdout, _, err := runCmd(t, api, "dns", "list", "example.com", "--page", "2", "--page-size", "1")
+	if err != nil || !strings.Contains(stdout, "Showing 2–2 of 3") {
+		t.Errorf("table output: %v, want the page footer:\n%s", err, stdout)
+	}
+
+	tee := filepath.Join(t.TempDir(), "tee.json")
+	stdout, _, err = runCmd(t, api, "dns", "list", "example.com", "--page", "2", "--page-size", "1", "-o", "env", "--tee", tee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		if !strings.HasPrefix(line, "CF_") {
+			t.Errorf("env output has a line that is not an assignment: %q", line)
+		}
+	}
+	if data, _ := os.ReadFile(tee); !strings.Contains(string(data), "Showing 2–2 of 3") {
+		t.Errorf("tee does not have the page footer:\n%s", data)
+	}
+
+	stdout, _, err = runCmd(t, api, "dns", "list", "example.com", "--page", "2", "--page-size", "1", "--template", "{{.Name}}")
+	if err != nil || strings.TrimSpace(stdout) != "b.example.com" {
+		t.Errorf("template output: %v, want only the record name:\n%s", err, stdout)
+	}
+}
+
+func TestDNSListGroupByOutput(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "www", Content: "192.0.2.1"})
+
+	stdout, _, err := runCmd(t, api, "dns", "list", "example.com", "--group-by", "type")
+	if err != nil || !strings.Contains(stdout, "A (1)") || !strings.Contains(stdout, "1 record(s) in 1 type(s)") {
+		t.Errorf("table output: %v, want the group heading and summary:\n%s", err, stdout)
+	}
+
+	tee := filepath.Join(t.TempDir(), "tee.json")
+	stdout, _, err = runCmd(t, api, "dns", "list", "example.com", "--group-by", "type", "-o", "env", "--tee", tee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		if !strings.HasPrefix(line, "CF_") {
+			t.Errorf("env output has a line that is not an assignment: %q", line)
+		}
+	}
+	var groups map[string][]client.DNSRecord
+	if data, _ := os.ReadFile(tee); json.Unmarshal(data, &groups) != nil || len(groups["A"]) != 1 {
+		t.Errorf("tee does not hold the records grouped by type:\n%s", data)
+	}
+
+	stdout, _, err = runCmd(t, api, "dns", "list", "example.com", "--group-by", "type", "--template", "{{.Name}}")
+	if err != nil || strings.TrimSpace(stdout) != "www.example.com" {
+		t.Errorf("template output: %v, want only the record name:\n%s", err, stdout)
+	}
+}
+
+func TestDNSAuditSummary(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "www", Content: "192.0.2.1", Proxiable: true, Proxied: cloudflare.BoolPtr(true)})
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "origin", Content: "192.0.2.2", Proxiable: true})
+
+	stdout, _, err := runCmd(t, api, "dns", "audit", "example.com")
+	if err != nil || !strings.Contains(stdout, "1 of 2 proxiable records are proxied.") {
+		t.Errorf("table output: %v, want the summary:\n%s", err, stdout)
+	}
+
+	stdout, _, err = runCmd(t, api, "dns", "audit", "example.com", "--template", "{{.Name}}")
+	if err != nil || strings.Contains(stdout, "proxiable") {
+		t.Errorf("template output: %v, want only the records:\n%s", err, stdout)
+	}
+}
+
+func TestDNSListDuplicatesFooter(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "www", Content: "192.0.2.1"})
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "www", Content: "192.0.2.1"})
+
+	stdout, _, err := runCmd(t, api, "dns", "list", "example.com", "--duplicates")
+	if err != nil || !strings.Contains(stdout, "1 group(s) of duplicate or conflicting records") {
+		t.Errorf("table output: %v, want the footer:\n%s", err, stdout)
+	}
+
+	stdout, _, err = runCmd(t, api, "dns", "list", "example.com", "--duplicates", "--template", "{{.Issue}}")
+	if err != nil || strings.Contains(stdout, "group(s)") {
+		t.Errorf("template output: %v, want only the duplicates:\n%s", err, stdout)
+	}
+}
+
+func TestDNSExportSplitSummary(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "www", Content: "192.0.2.1"})
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "TXT", Name: "www", Content: `"hello"`})
+	dir := t.TempDir()
+
+	stdout, _, err := runCmd(t, api, "dns", "export", "example.com", "--dir", dir, "--split-by-type")
+	if err != nil || !strings.Contains(stdout, "Exported 2 record(s) to 2 file(s)") {
+		t.Errorf("table output: %v, want the summary:\n%s", err, stdout)
+	}
+
+	stdout, _, err = runCmd(t, api, "dns", "export", "example.com", "--dir", dir, "--split-by-type", "--template", "{{.Type}}")
+	if err != nil || strings.Contains(stdout, "Exported") {
+		t.Errorf("template output: %v, want only the files:\n%s", err, stdout)
+	}
+}
+
+func TestDNSDiffOutput(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "www", Content: "192.0.2.1", TTL: 300})
+	file := filepath.Join(t.TempDir(), "example.com.zone")
+	if err := os.WriteFile(file, []byte("www.example.com. 300 IN A 192.0.2.2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, _, err := runCmd(t, api, "dns", "diff", "example.com", file)
+	if err != nil || !strings.Contains(stdout, "+ A www.example.com 192.0.2.2") || !strings.Contains(stdout, "1 to add, 1 to remove, 0 to change") {
+		t.Errorf("table output: %v, want the unified diff and summary:\n%s", err, stdout)
+	}
+
+	stdout, _, err = runCmd(t, api, "dns", "diff", "example.com", file, "--template", "{{.Action}}")
+	if err != nil || strings.TrimSpace(stdout) != "add\nremove" {
+		t.Errorf("template output: %v, want only the side-by-side actions:\n%s", err, stdout)
+	}
+
+	if _, _, err := runCmd(t, api, "dns", "diff", "example.com", file, "--diff-format", "unified", "-o", "env"); err == nil {
+		t.Error("unified diff as env output: want an error")
+	}
+}
+
+func TestDNSListExpandTXTOutput(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "TXT", Name: "@", Content: `"v=spf1 include:_spf.example.net -all"`})
+
+	stdout, _, err := runCmd(t, api, "dns", "list", "example.com", "--expand-txt")
+	if err != nil || !strings.Contains(stdout, "include:_spf.example.net") || !strings.Contains(stdout, "\nSPF example.com") {
+		t.Errorf("table output: %v, want the SPF expansion:\n%s", err, stdout)
+	}
+
+	stdout, _, err = runCmd(t, api, "dns", "list", "example.com", "--expand-txt", "-o", "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		if !strings.HasPrefix(line, "CF_") {
+			t.Errorf("env output has a line that is not an assignment: %q", line)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+)
+
+// txtTerm is one mechanism or tag of an expanded TXT record
+type txtTerm struct {
+	Term        string `json:"term"`
+	Description string `json:"description"`
+}
+
+// txtExpansion is a TXT record recognized as SPF, DKIM, or DMARC, split into its terms
+type txtExpansion struct {
+	Record *client.DNSRecord `json:"record"`
+	Kind   string            `json:"kind"`
+	Terms  []txtTerm         `json:"terms"`
+}
+
+// spfQualifiers are the results an SPF mechanism can have
+var spfQualifiers = map[byte]string{'+': "pass", '-': "fail", '~': "soft-fail", '?': "neutral"}
+
+// dmarcTags describes the tags of a DMARC policy
+var dmarcTags = map[string]string{
+	"v":     "version",
+	"p":     "policy for the domain",
+	"sp":    "policy for subdomains",
+	"pct":   "percentage of failing mail the policy applies to",
+	"rua":   "where aggregate reports are sent",
+	"ruf":   "where failure reports are sent",
+	"adkim": "DKIM alignment (r relaxed, s strict)",
+	"aspf":  "SPF alignment (r relaxed, s strict)",
+	"fo":    "when failure reports are generated",
+	"rf":    "failure report format",
+	"ri":    "aggregate report interval in seconds",
+}
+
+// dkimTags describes the tags of a DKIM key record
+var dkimTags = map[string]string{
+	"v": "version",
+	"k": "key type",
+	"p": "public key (empty means the key is revoked)",
+	"h": "acceptable hash algorithms",
+	"s": "service types",
+	"t": "flags (y testing, s strict)",
+	"n": "notes",
+}
+
+// expandTXT recognizes SPF, DKIM, and DMARC records and splits them into
+// annotated terms. It returns nil for other records.
+func expandTXT(r *client.DNSRecord) *txtExpansion {
+	if !strings.EqualFold(r.Type, "TXT") {
+		return nil
+	}
+	text := unquoteTXT(r.Content)
+	lower := strings.ToLower(text)
+	switch {
+	case strings.HasPrefix(lower, "v=spf1"):
+		return &txtExpansion{Record: r, Kind: "SPF", Terms: expandSPF(text)}
+	case strings.HasPrefix(lower, "v=dmarc1"):
+		return &txtExpansion{Record: r, Kind: "DMARC", Terms: expandTags(text, dmarcTags)}
+	case strings.HasPrefix(lower, "v=dkim1") || strings.Contains(strings.ToLower(r.Name), "._domainkey."):
+		return &txtExpansion{Record: r, Kind: "DKIM", Terms: expandTags(text, dkimTags)}
+	}
+	return nil
+}
+
+// expandSPF describes each mechanism and modifier of an SPF record
+func expandSPF(text string) []txtTerm {
+	var terms []txtTerm
+	for _, field := range strings.Fields(text) {
+		terms = append(terms, txtTerm{Term: field, Description: describeSPFTerm(field)})
+	}
+	return terms
+}
+
+// describeSPFTerm explains a single SPF term
+func describeSPFTerm(term string) string {
+	if strings.EqualFold(term, "v=spf1") {
+		return "version"
+	}
+	if name, value, ok := strings.Cut(term, "="); ok {
+		switch strings.ToLower(name) {
+		case "redirect":
+			return "use the SPF policy of " + value + " instead"
+		case "exp":
+			return "explanation for failures is at " + value
+		}
+		return "unknown modifier"
+	}
+
+	result := "pass"
+	if q, ok := spfQualifiers[term[0]]; ok {
+		result = q
+		term = term[1:]
+	}
+	mechanism, arg, _ := strings.Cut(term, ":")
+	switch strings.ToLower(mechanism) {
+	case "all":
+		return result + " for all other senders"
+	case "include":
+		return fmt.Sprintf("%s if the SPF policy of %s passes", result, arg)
+	case "ip4", "ip6":
+		return fmt.Sprintf("%s for mail from %s", result, arg)
+	case "a", "mx":
+		target := "this domain"
+		if arg != "" {
+			target = arg
+		}
+		return fmt.Sprintf("%s for the %s hosts of %s", result, strings.ToUpper(mechanism), target)
+	case "exists":
+		return fmt.Sprintf("%s if %s resolves", result, arg)
+	case "ptr":
+		return result + " by reverse DNS (deprecated)"
+	}
+	if strings.HasPrefix(strings.ToLower(mechanism), "a/") || strings.HasPrefix(strings.ToLower(mechanism), "mx/") {
+		return fmt.Sprintf("%s for the network of the %s hosts of this domain", result, strings.ToUpper(strings.SplitN(mechanism, "/", 2)[0]))
+	}
+	return "unknown mechanism"
+}
+
+// expandTags describes the tag=value pairs of a DMARC or DKIM record
+func expandTags(text string, known map[string]string) []txtTerm {
+	var terms []txtTerm
+	for _, part := range strings.Split(text, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		tag, _, _ := strings.Cut(part, "=")
+		description, ok := known[strings.ToLower(strings.TrimSpace(tag))]
+		if !ok {
+			description = "unknown tag"
+		}
+		terms = append(terms, txtTerm{Term: part, Description: description})
+	}
+	return terms
+}
+
+// expandTXTRecords returns the expansions of the SPF, DKIM, and DMARC records
+func expandTXTRecords(records []client.DNSRecord) []txtExpansion {
+	expansions := []txtExpansion{}
+	for i := range records {
+		if e := expandTXT(&records[i]); e != nil {
+			expansions = append(expansions, *e)
+		}
+	}
+	return expansions
+}
+
+// writeTXTExpansions writes the expanded SPF, DKIM, and DMARC records after
+// the record table (table output only), or as a JSON array of expansions
+func writeTXTExpansions(expansions []txtExpansion) error {
+	if outputFormat == "json" {
+		return out.WriteJSON(expansions)
+	}
+	for _, e := range expansions {
+		var b strings.Builder
+		fmt.Fprintf(&b, "\n%s %s (%s)", e.Kind, e.Record.Name, e.Record.ID)
+		width := 0
+		for _, t := range e.Terms {
+			width = max(width, len(t.Term))
+		}
+		for _, t := range e.Terms {
+			fmt.Fprintf(&b, "\n  %-*s  %s", width, t.Term, t.Description)
+		}
+		out.WriteNote(b.String())
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/coollabsio/cloudflare-cli/internal/resolver"
+	"github.com/spf13/cobra"
+)
+
+// verifyLookupTimeout bounds the live DNS query of dns verify
+const verifyLookupTimeout = 5 * time.Second
+
+// verifyTypes are the record types dns verify can query
+var verifyTypes = []string{"A", "AAAA", "CNAME", "MX", "NS", "PTR", "TXT"}
+
+// errServedMismatch is returned by dns verify when the live answer differs
+var errServedMismatch = errors.New("served DNS answer does not match the configured record")
+
+// recordVerification is the result of dns verify
+type recordVerification struct {
+	Record    *client.DNSRecord `json:"record"`
+	QueryType string            `json:"query_type"`
+	Served    []string          `json:"served"`
+	Match     bool              `json:"match"`
+}
+
+var dnsVerifyCmd = &cobra.Command{
+	Use:   "verify <zone> <record-id>",
+	Short: "Check that a record is served as configured",
+	Long: `Fetch a record from the API and compare it with a live DNS query for the same
+name and type through the system resolver. Exits non-zero if the served answer
+does not match, so it can be used for monitoring.
+
+Proxied records are not served with their configured content: they match when
+every served address is a Cloudflare proxy address (A/AAAA answers are
+queried for proxied A, AAAA, and CNAME records).
+
+Supported types: A, AAAA, CNAME, MX, NS, PTR, TXT. Answers may be cached by
+the resolver, so a recent change can show as a mismatch until its TTL expires.
+
+Examples:
+  cf dns verify example.com 372e67954025e0ba6aaa6d586b9e0b59
+  cf dns verify example.com 372e67954025e0ba6aaa6d586b9e0b59 -o json`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		zoneID, err := resolveZone(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		record, err := c.GetDNSRecord(ctx, zoneID, args[1])
+		if err != nil {
+			return err
+		}
+
+		queryType := strings.ToUpper(record.Type)
+		if !slices.Contains(verifyTypes, queryType) {
+			return fmt.Errorf("live verification is not supported for %s records (supported: %s)", queryType, strings.Join(verifyTypes, ", "))
+		}
+		if record.Proxied && queryType == "CNAME" {
+			queryType = "A"
+		}
+
+		lookupCtx, cancel := context.WithTimeout(ctx, verifyLookupTimeout)
+		defer cancel()
+		stop := startSpinner("Querying live DNS...")
+		served, err := resolver.Query(lookupCtx, record.Name, queryType)
+		stop()
+		if err != nil {
+			return err
+		}
+
+		match := servedMatches(record, served)
+
+		// From here on a failure means "mismatch", not a usage problem
+		cmd.SilenceUsage = true
+		if served == nil {
+			served = []string{}
+		}
+		result := recordVerification{Record: record, QueryType: queryType, Served: served, Match: match}
+		if outputFormat == "json" {
+			if err := out.WriteJSON(result); err != nil {
+				return err
+			}
+		} else {
+			out.TeeJSON(result)
+			configured := record.Content
+			if record.Proxied {
+				configured = "(proxied) " + configured
+			}
+			result := "match"
+			if !match {
+				result = "MISMATCH"
+			}
+			headers := []string{"Name", "Type", "Configured", "Served", "Result"}
+			rows := [][]string{{record.Name, record.Type, configured, strings.Join(served, ", "), result}}
+			if err := out.WriteTable(headers, rows); err != nil {
+				return err
+			}
+		}
+
+		if !match {
+			return errServedMismatch
+		}
+		return nil
+	},
+}
+
+func init() {
+	dnsCmd.AddCommand(dnsVerifyCmd)
+}
+
+// servedMatches reports whether the live answer matches the configured record.
+// Proxied records must be served from Cloudflare addresses only.
+func servedMatches(record *client.DNSRecord, served []string) bool {
+	if len(served) == 0 {
+		return false
+	}
+	if record.Proxied {
+		for _, addr := range served {
+			if !resolver.IsCloudflareIP(addr) {
+				return false
+			}
+		}
+		return true
+	}
+
+	want := record.Content
+	switch strings.ToUpper(record.Type) {
+	case "A", "AAAA":
+		if ip := net.ParseIP(want); ip != nil {
+			want = ip.String()
+		}
+	case "CNAME", "MX", "NS", "PTR":
+		want = resolver.Normalize(want)
+	case "TXT":
+		want = unquoteTXT(want)
+	}
+	return slices.Contains(served, want)
+}
+
+// unquoteTXT turns TXT content written as quoted strings ("a" "b") into the
+// text that is served, leaving unquoted content as it is
+func unquoteTXT(content string) string {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, `"`) {
+		return content
+	}
+
+	var b strings.Builder
+	inQuotes, escaped := false, false
+	for _, ch := range content {
+		switch {
+		case escaped:
+			b.WriteRune(ch)
+			escaped = false
+		case ch == '\\' && inQuotes:
+			escaped = true
+		case ch == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+			b.WriteRune(ch)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/coollabsio/cloudflare-cli/internal/version"
+	"github.com/creativeprojects/go-selfupdate"
+	goversion "github.com/hashicorp/go-version"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// doctorTimeout bounds each network check of cf doctor
+	doctorTimeout = 10 * time.Second
+	// maxClockSkewWarn and maxClockSkewFail are how far the local clock may be
+	// off from the API's before cf doctor warns or fails
+	maxClockSkewWarn = 30 * time.Second
+	maxClockSkewFail = 5 * time.Minute
+)
+
+// doctorCheck is a single result of cf doctor. Status is ok, warn, or fail;
+// only failures make the command exit non-zero.
+type doctorCheck struct {
+	Check  string `json:"check"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// doctorReport is the result of cf doctor
+type doctorReport struct {
+	Healthy bool          `json:"healthy"`
+	Checks  []doctorCheck `json:"checks"`
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that cf is set up and can reach the API",
+	Long: `Run a series of checks and print a checklist:
+
+  config_file   the config file parses
+  credentials   credentials are configured
+  min_ttl       the TTL policy of the config, if any
+  network       the API endpoint is reachable
+  clock         the local clock agrees with the API's (warns past 30s, fails past 5m)
+  verify        the credentials are accepted by the API
+  version       the installed version is the latest release (warning only)
+
+Exits non-zero if any check fails; warnings don't change the exit code.
+
+Examples:
+  cf doctor
+  cf doctor -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var checks []doctorCheck
+		add := func(check, status, detail string) {
+			checks = append(checks, doctorCheck{check, status, detail})
+		}
+
+		// status turns the result of a check shared with config validate
+		// into ok or fail
+		status := func(ok bool) string {
+			if ok {
+				return "ok"
+			}
+			return "fail"
+		}
+
+		ok, detail := configFileCheck()
+		add("config_file", status(ok), detail)
+
+		credsOK, detail := credentialsCheck()
+		add("credentials", status(credsOK), detail)
+
+		ok, detail = minTTLCheck()
+		add("min_ttl", status(ok), detail)
+
+		stop := startSpinner("Running checks...")
+		serverTime, netErr := probeAPI(configBaseURL())
+		if netErr != nil {
+			add("network", "fail", netErr.Error())
+			add("clock", "warn", "not checked: the API is unreachable")
+		} else {
+			add("network", "ok", configBaseURL())
+			add(clockCheck(time.Since(serverTime)))
+		}
+
+		switch {
+		case !credsOK:
+			add("verify", "fail", "not checked: no usable credentials")
+		case netErr != nil:
+			add("verify", "fail", "not checked: the API is unreachable")
+		default:
+			if err := verifyCredentials(cfg); err != nil {
+				add("verify", "fail", err.Error())
+			} else {
+				add("verify", "ok", "credentials are valid")
+			}
+		}
+
+		add(versionCheck())
+		stop()
+
+		failed := 0
+		for _, c := range checks {
+			if c.Status == "fail" {
+				failed++
+			}
+		}
+
+		result := doctorReport{Healthy: failed == 0, Checks: checks}
+		if outputFormat == "json" {
+			if err := out.WriteJSON(result); err != nil {
+				return err
+			}
+		} else {
+			out.TeeJSON(result)
+			headers := []string{"Check", "Status", "Detail"}
+			var rows [][]string
+			for _, c := range checks {
+				status := "ok"
+				switch c.Status {
+				case "warn":
+					status = "WARN"
+				case "fail":
+					status = "FAIL"
+				}
+				rows = append(rows, []string{c.Check, status, c.Detail})
+			}
+			if err := out.WriteTable(headers, rows); err != nil {
+				return err
+			}
+		}
+
+		if failed > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d check(s) failed", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// probeAPI makes an unauthenticated request to the API endpoint and returns
+// the server time from its Date header. Any HTTP response counts as reachable.
+func probeAPI(baseURL string) (time.Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for name, value := range cfg.Headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot reach %s: %w", baseURL, err)
+	}
+	resp.Body.Close()
+
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return time.Time{}, errors.New("the API response has no valid Date header")
+	}
+	return serverTime, nil
+}
+
+// clockCheck rates the difference between the local clock and the API's
+func clockCheck(skew time.Duration) (string, string, string) {
+	abs := skew.Abs().Round(time.Second)
+	detail := fmt.Sprintf("local clock is %s ahead of the API", abs)
+	if skew < 0 {
+		detail = fmt.Sprintf("local clock is %s behind the API", abs)
+	}
+	switch {
+	case abs > maxClockSkewFail:
+		return "clock", "fail", detail
+	case abs > maxClockSkewWarn:
+		return "clock", "warn", detail
+	}
+	return "clock", "ok", fmt.Sprintf("within %s of the API", maxClockSkewWarn)
+}
+
+// versionCheck compares the installed version with the latest release
+func versionCheck() (string, string, string) {
+	current := version.GetVersion()
+	if current == "dev" {
+		return "version", "ok", "dev build, not checked"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+	latest, found, err := selfupdate.DetectLatest(ctx, selfupdate.ParseSlug("coollabsio/cloudflare-cli"))
+	if err != nil || !found {
+		return "version", "warn", fmt.Sprintf("%s (could not check for the latest release)", current)
+	}
+	currentVersion, err := goversion.NewVersion(current)
+	if err != nil {
+		return "version", "warn", fmt.Sprintf("%s (not a release version)", current)
+	}
+	latestVersion, err := goversion.NewVersion(latest.Version())
+	if err == nil && latestVersion.GreaterThan(currentVersion) {
+		return "version", "warn", fmt.Sprintf("%s installed, %s available (run 'cf update')", current, latest.Version())
+	}
+	return "version", "ok", fmt.Sprintf("%s (latest)", current)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/coollabsio/cloudflare-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Set up cf interactively",
+	Long: `Interactively set up cf: choose an authentication method, enter your
+credentials (input is hidden), verify them against the API, pick a default
+output format, and write the config file (~/.cloudflare/config.yaml, or the
+path from --config / $CLOUDFLARE_CONFIG).
+
+cf init needs an interactive terminal. In scripts and CI, set
+CLOUDFLARE_API_TOKEN or use "cf auth save <token>" instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !isTerminal(os.Stdin) || !isTerminal(os.Stderr) {
+			return errors.New(`cf init needs an interactive terminal; set CLOUDFLARE_API_TOKEN or run "cf auth save <token>" instead`)
+		}
+
+		configPath := config.ResolvePath(cfgFile)
+		if exists, _ := config.CheckFile(configPath); exists && !confirm(fmt.Sprintf("%s already exists. Overwrite its settings?", configPath)) {
+			return errors.New("aborted: config file left unchanged")
+		}
+
+		// Keep settings that the wizard doesn't ask about, from the file alone
+		// so secret files and the environment are never written to it
+		newCfg := config.LoadFile(configPath, cfg.Profile)
+		newCfg.APIToken, newCfg.APIKey, newCfg.APIEmail = "", "", ""
+
+		fmt.Fprintln(os.Stderr, "How do you want to authenticate?")
+		fmt.Fprintln(os.Stderr, "  1) API token (recommended)")
+		fmt.Fprintln(os.Stderr, "  2) Global API key + email")
+		var err error
+		switch promptLine("Choice", "1") {
+		case "1":
+			newCfg.APIToken, err = promptSecret("API token")
+		case "2":
+			newCfg.APIEmail = promptLine("Account email", "")
+			newCfg.APIKey, err = promptSecret("Global API key")
+		default:
+			return errors.New("invalid choice: enter 1 or 2")
+		}
+		if err != nil {
+			return err
+		}
+
+		c, err := client.New(newCfg)
+		if err != nil {
+			return err
+		}
+		stop := startSpinner("Verifying credentials...")
+		err = c.VerifyToken(context.Background())
+		stop()
+		if err != nil {
+			return fmt.Errorf("credential verification failed: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, "Credentials verified.")
+
+		def := newCfg.OutputFormat
+		if def == "" {
+			def = "table"
+		}
+		format := promptLine("Default output format (table, json)", def)
+		if format != "table" && format != "json" {
+			return fmt.Errorf("invalid output format: %s (must be 'table' or 'json')", format)
+		}
+		newCfg.OutputFormat = format
+
+		if err := newCfg.Save(configPath); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		out.WriteSuccess(fmt.Sprintf("Config saved to %s", configPath))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+}
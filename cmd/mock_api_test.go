@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// mockAPI is an in-memory Cloudflare API for command tests. It serves the
+// zone and DNS record endpoints the commands use and records every request.
+type mockAPI struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	zones    []cloudflare.Zone
+	records  map[string][]cloudflare.DNSRecord
+	nextID   int
+	requests []string
+}
+
+// newMockAPI starts a mock API serving the given zones, stopped when the test ends
+func newMockAPI(t *testing.T, zoneNames ...string) *mockAPI {
+	t.Helper()
+	m := &mockAPI{records: make(map[string][]cloudflare.DNSRecord)}
+	for i, name := range zoneNames {
+		m.zones = append(m.zones, cloudflare.Zone{ID: fmt.Sprintf("%032x", i+1), Name: name, Status: "active"})
+	}
+	m.Server = httptest.NewServer(http.HandlerFunc(m.serve))
+	t.Cleanup(m.Close)
+	return m
+}
+
+// zoneID returns the ID of a zone served by the mock
+func (m *mockAPI) zoneID(name string) string {
+	for _, z := range m.zones {
+		if z.Name == name {
+			return z.ID
+		}
+	}
+	return ""
+}
+
+// addRecord stores a record in a zone as if it had been created earlier
+func (m *mockAPI) addRecord(zoneName string, r cloudflare.DNSRecord) cloudflare.DNSRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.store(m.zoneID(zoneName), zoneName, r)
+}
+
+// zoneRecords returns the records currently stored in a zone
+func (m *mockAPI) zoneRecords(zoneName string) []cloudflare.DNSRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]cloudflare.DNSRecord(nil), m.records[m.zoneID(zoneName)]...)
+}
+
+// store assigns an ID and a fully qualified name to a record and keeps it,
+// as Cloudflare does on create
+func (m *mockAPI) store(zoneID, zoneName string, r cloudflare.DNSRecord) cloudflare.DNSRecord {
+	m.nextID++
+	r.ID = fmt.Sprintf("rec%d", m.nextID)
+	switch {
+	case r.Name == "@" || r.Name == "":
+		r.Name = zoneName
+	case r.Name != zoneName && !strings.HasSuffix(r.Name, "."+zoneName):
+		r.Name += "." + zoneName
+	}
+	if r.TTL == 0 {
+		r.TTL = 1
+	}
+	r.Proxiable = r.Type == "A" || r.Type == "AAAA" || r.Type == "CNAME"
+	if r.Proxied == nil {
+		r.Proxied = cloudflare.BoolPtr(false)
+	}
+	m.records[zoneID] = append(m.records[zoneID], r)
+	return r
+}
+
+func (m *mockAPI) serve(w http.ResponseWriter, req *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, req.Method+" "+req.URL.Path)
+
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "zones" && req.Method == http.MethodGet:
+		var zones []cloudflare.Zone
+		for _, z := range m.zones {
+			if name := req.URL.Query().Get("name"); name == "" || name == z.Name {
+				zones = append(zones, z)
+			}
+		}
+		writeMockList(w, zones, len(zones))
+		return
+	case len(parts) < 2 || parts[0] != "zones":
+		writeMockError(w, http.StatusNotFound, 7003, "Could not route to "+req.URL.Path)
+		return
+	}
+
+	zoneID := parts[1]
+	var zone *cloudflare.Zone
+	for i := range m.zones {
+		if m.zones[i].ID == zoneID {
+			zone = &m.zones[i]
+		}
+	}
+	if zone == nil {
+		writeMockError(w, http.StatusNotFound, 1001, "Invalid zone identifier")
+		return
+	}
+
+	switch {
+	case len(parts) == 2 && req.Method == http.MethodGet:
+		writeMockResult(w, zone)
+	case len(parts) == 3 && parts[2] == "dns_records" && req.Method == http.MethodGet:
+		var records []cloudflare.DNSRecord
+		q := req.URL.Query()
+		for _, r := range m.records[zoneID] {
+			if (q.Get("type") == "" || q.Get("type") == r.Type) && (q.Get("name") == "" || q.Get("name") == r.Name) {
+				records = append(records, r)
+			}
+		}
+		writeMockList(w, records, len(records))
+	case len(parts) == 3 && parts[2] == "dns_records" && req.Method == http.MethodPost:
+		var r cloudflare.DNSRecord
+		if err := json.NewDecoder(req.Body).Decode(&r); err != nil {
+			writeMockError(w, http.StatusBadRequest, 9207, "Request body is invalid")
+			return
+		}
+		writeMockResult(w, m.store(zoneID, zone.Name, r))
+	case len(parts) == 4 && parts[2] == "dns_records":
+		m.serveRecord(w, req, zoneID, parts[3])
+	default:
+		writeMockError(w, http.StatusNotFound, 7003, "Could not route to "+req.URL.Path)
+	}
+}
+
+// serveRecord serves the endpoints of a single record
+func (m *mockAPI) serveRecord(w http.ResponseWriter, req *http.Request, zoneID, recordID string) {
+	records := m.records[zoneID]
+	i := -1
+	for j := range records {
+		if records[j].ID == recordID {
+			i = j
+		}
+	}
+	if i < 0 {
+		writeMockError(w, http.StatusNotFound, 81044, "Record does not exist.")
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		writeMockResult(w, records[i])
+	case http.MethodPatch, http.MethodPut:
+		if err := json.NewDecoder(req.Body).Decode(&records[i]); err != nil {
+			writeMockError(w, http.StatusBadRequest, 9207, "Request body is invalid")
+			return
+		}
+		records[i].ID = recordID
+		writeMockResult(w, records[i])
+	case http.MethodDelete:
+		m.records[zoneID] = append(records[:i], records[i+1:]...)
+		writeMockResult(w, map[string]string{"id": recordID})
+	default:
+		writeMockError(w, http.StatusMethodNotAllowed, 10000, "Method not allowed")
+	}
+}
+
+// writeMockResult writes a successful API response
+func writeMockResult(w http.ResponseWriter, result interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true, "errors": []interface{}{}, "messages": []interface{}{}, "result": result,
+	})
+}
+
+// writeMockList writes a successful single-page list response
+func writeMockList(w http.ResponseWriter, result interface{}, count int) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true, "errors": []interface{}{}, "messages": []interface{}{}, "result": result,
+		"result_info": map[string]int{"page": 1, "per_page": 100, "count": count, "total_count": count, "total_pages": 1},
+	})
+}
+
+// writeMockError writes a failed API response
+func writeMockError(w http.ResponseWriter, status, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false, "messages": []interface{}{}, "result": nil,
+		"errors": []map[string]interface{}{{"code": code, "message": message}},
+	})
+}
+
+// runCmd runs cf with args against the mock API and returns what it wrote to
+// stdout and stderr. Flags are reset to their defaults first, since the
+// command tree is shared by every test.
+func runCmd(t *testing.T, api *mockAPI, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	resetFlags(rootCmd)
+	t.Setenv("CLOUDFLARE_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	t.Setenv("CLOUDFLARE_API_TOKEN", "test-token")
+	t.Setenv("CLOUDFLARE_API_BASE_URL", api.URL)
+	t.Setenv("CLOUDFLARE_PROFILE", "")
+
+	stdoutR, stdoutW, _ := os.Pipe()
+	stderrR, stderrW, _ := os.Pipe()
+	origStdout, origStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdoutW, stderrW
+	var outBuf, errBuf bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); io.Copy(&outBuf, stdoutR) }()
+	go func() { defer wg.Done(); io.Copy(&errBuf, stderrR) }()
+
+	rootCmd.SetArgs(append([]string{"--no-retry"}, args...))
+	err = rootCmd.Execute()
+
+	os.Stdout, os.Stderr = origStdout, origStderr
+	stdoutW.Close()
+	stderrW.Close()
+	wg.Wait()
+	return outBuf.String(), errBuf.String(), err
+}
+
+// resetFlags sets every flag of cmd and its subcommands back to its default
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			s.Replace(nil)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, sub := range cmd.Commands() {
+		resetFlags(sub)
+	}
+}
@@ -1,9 +1,15 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strings"
+	"text/template"
 
+	"github.com/coollabsio/cloudflare-cli/internal/client"
 	"github.com/coollabsio/cloudflare-cli/internal/config"
+	"github.com/coollabsio/cloudflare-cli/internal/logging"
 	"github.com/coollabsio/cloudflare-cli/internal/output"
 	"github.com/coollabsio/cloudflare-cli/internal/version"
 	"github.com/spf13/cobra"
@@ -12,8 +18,27 @@ import (
 var (
 	cfgFile      string
 	outputFormat string
+	dryRun       bool
+	jsonCompact  bool
+	strictJSON   bool
+	maxColWidth  int
+	truncate     bool
+	noTruncate   bool
+	logFormat    string
+	verbose      bool
+	retryOn      string
+	noRetry      bool
 	cfg          *config.Config
 	out          *output.Writer
+
+	outputTemplate     string
+	outputTemplateFile string
+	extraHeaders       []string
+	printCurl          bool
+	apiURL             string
+	profileName        string
+	teePath            string
+	teeFile            *os.File
 )
 
 // rootCmd represents the base command
@@ -28,16 +53,48 @@ Configure authentication using environment variables:
   CLOUDFLARE_API_KEY + CLOUDFLARE_API_EMAIL
 
 Or create a config file at ~/.cloudflare/config.yaml:
-  api_token: your-token-here`,
+  api_token: your-token-here
+
+The config file path can be overridden with --config or the
+CLOUDFLARE_CONFIG (or CF_CONFIG) environment variable.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Start async update check (non-blocking)
 		version.StartUpdateCheck()
 
+		if err := logging.Setup(logFormat, verbose, os.Stderr); err != nil {
+			return err
+		}
+
 		var err error
-		cfg, err = config.Load(cfgFile)
+		cfg, err = config.LoadProfile(cfgFile, profileName)
 		if err != nil {
 			return err
 		}
+		cfg.DryRun = dryRun
+		cfg.PrintCurl = printCurl
+		if apiURL != "" {
+			cfg.APIBaseURL = apiURL
+		}
+		cfg.NoRetry = noRetry
+		for _, h := range extraHeaders {
+			name, value, err := client.ParseHeader(h)
+			if err != nil {
+				return err
+			}
+			if cfg.Headers == nil {
+				cfg.Headers = make(map[string]string)
+			}
+			cfg.Headers[name] = value
+		}
+		if cmd.Flags().Changed("retry-on") {
+			if noRetry {
+				return fmt.Errorf("--retry-on and --no-retry cannot be used together")
+			}
+			cfg.RetryOn, err = client.ParseRetryStatuses(retryOn)
+			if err != nil {
+				return err
+			}
+		}
 
 		// Determine output format: flag > config > default
 		format := output.FormatTable
@@ -46,13 +103,48 @@ Or create a config file at ~/.cloudflare/config.yaml:
 		}
 		// Command-line flag overrides config
 		if cmd.Flags().Changed("output") {
-			if outputFormat == "json" {
+			switch outputFormat {
+			case "json":
 				format = output.FormatJSON
-			} else {
+			case "template":
+				format = output.FormatTemplate
+			case "env":
+				format = output.FormatEnv
+			default:
 				format = output.FormatTable
 			}
 		}
+		// A template implies template output
+		if outputTemplate != "" || outputTemplateFile != "" {
+			format = output.FormatTemplate
+			outputFormat = "template"
+		}
 		out = output.NewWriter(format)
+		out.SetCompact(jsonCompact)
+		out.SetStrict(strictJSON)
+		// --max-col-width implies --truncate; --no-truncate wins over both
+		if (truncate || cmd.Flags().Changed("max-col-width")) && !noTruncate {
+			if maxColWidth < 1 {
+				return fmt.Errorf("invalid --max-col-width: %d (must be at least 1)", maxColWidth)
+			}
+			out.SetMaxColWidth(maxColWidth)
+		}
+		if teePath != "" {
+			f, err := os.Create(teePath)
+			if err != nil {
+				return fmt.Errorf("failed to create --tee file: %w", err)
+			}
+			teeFile = f
+			out.SetTee(f)
+		}
+
+		if format == output.FormatTemplate {
+			tmpl, err := loadOutputTemplate()
+			if err != nil {
+				return err
+			}
+			out.SetTemplate(tmpl)
+		}
 		return nil
 	},
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
@@ -61,15 +153,75 @@ Or create a config file at ~/.cloudflare/config.yaml:
 	},
 }
 
+// loadOutputTemplate parses the template from --template or --template-file
+func loadOutputTemplate() (*template.Template, error) {
+	if outputTemplate != "" && outputTemplateFile != "" {
+		return nil, fmt.Errorf("--template and --template-file cannot be used together")
+	}
+
+	text := outputTemplate
+	if outputTemplateFile != "" {
+		data, err := os.ReadFile(outputTemplateFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template file: %w", err)
+		}
+		text = strings.TrimRight(string(data), "\n")
+	}
+	if text == "" {
+		return nil, fmt.Errorf("-o template requires --template or --template-file")
+	}
+	return output.ParseTemplate(text)
+}
+
 // Execute runs the root command
 func Execute() {
 	err := rootCmd.Execute()
+	if teeErr := closeTee(); teeErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write --tee file: %v\n", teeErr)
+		if err == nil {
+			os.Exit(1)
+		}
+	}
 	if err != nil {
+		// Translated API errors hide the raw Cloudflare response; show it on request
+		var apiErr *client.APIError
+		if verbose && errors.As(err, &apiErr) {
+			fmt.Fprintf(os.Stderr, "Cloudflare response: %v\n", apiErr.Err)
+		}
 		os.Exit(1)
 	}
 }
 
+// closeTee closes the --tee file, returning the first error writing to it
+func closeTee() error {
+	if teeFile == nil {
+		return nil
+	}
+	err := out.TeeErr()
+	if closeErr := teeFile.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
 func init() {
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is ~/.cloudflare/config.yaml)")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "output format (table, json)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $CLOUDFLARE_CONFIG or ~/.cloudflare/config.yaml)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "output format (table, json, template, env)")
+	rootCmd.PersistentFlags().StringVar(&outputTemplate, "template", "", "Go template rendered for each item (e.g. '{{.Name}} {{.Content}}')")
+	rootCmd.PersistentFlags().StringVar(&outputTemplateFile, "template-file", "", "read the output Go template from a file")
+	rootCmd.PersistentFlags().BoolVar(&jsonCompact, "compact", false, "print JSON on a single line instead of indented")
+	rootCmd.PersistentFlags().BoolVar(&strictJSON, "strict-json", false, "check JSON output against its schema and fail on a mismatch or an output without one")
+	rootCmd.PersistentFlags().IntVar(&maxColWidth, "max-col-width", 60, "with --truncate, shorten table cells to this many characters (implies --truncate)")
+	rootCmd.PersistentFlags().BoolVar(&truncate, "truncate", false, "shorten long table cells with an ellipsis (JSON keeps the full content)")
+	rootCmd.PersistentFlags().BoolVar(&noTruncate, "no-truncate", false, "never shorten table cells")
+	rootCmd.PersistentFlags().StringVar(&teePath, "tee", "", "also write the JSON form of the output to this file")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "log operational events to stderr (text, json)")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "show the raw Cloudflare API error alongside translated messages (with --log-format, also log debug events)")
+	rootCmd.PersistentFlags().StringVar(&retryOn, "retry-on", "429,500,502,503,504", "comma-separated HTTP statuses that are retried")
+	rootCmd.PersistentFlags().BoolVar(&noRetry, "no-retry", false, "disable retries of failed API requests")
+	rootCmd.PersistentFlags().StringArrayVar(&extraHeaders, "header", nil, "extra HTTP header for every API request, as 'Name: Value' (repeatable)")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "use the credentials of this profile from the config file (env CLOUDFLARE_PROFILE)")
+	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", "", "API base URL, e.g. for a regional endpoint or gateway (default "+client.DefaultBaseURL+"; env CLOUDFLARE_API_BASE_URL)")
+	rootCmd.PersistentFlags().BoolVar(&printCurl, "print-curl", false, "print each API request as an equivalent curl command to stderr (with --dry-run, also for skipped changes)")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "show what create/update/delete would do without changing anything")
 }
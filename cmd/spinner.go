@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// startSpinner shows msg with a spinner on stderr until the returned stop function is called.
+// Nothing is shown in JSON mode or when stdout/stderr are not terminals.
+func startSpinner(msg string) (stop func()) {
+	if outputFormat == "json" || !isTerminal(os.Stdout) || !isTerminal(os.Stderr) {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		frames := []string{"|", "/", "-", "\\"}
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for i := 0; ; i++ {
+			fmt.Fprintf(os.Stderr, "\r%s %s", frames[i%len(frames)], msg)
+			select {
+			case <-done:
+				// Clear the status line
+				fmt.Fprintf(os.Stderr, "\r%*s\r", len(msg)+2, "")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			wg.Wait()
+		})
+	}
+}
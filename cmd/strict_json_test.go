@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// TestStrictJSONOutputs runs the commands served by the mock API with
+// --strict-json, which fails any JSON output without a matching schema
+func TestStrictJSONOutputs(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+	www := api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "www", Content: "192.0.2.1", Proxied: cloudflare.BoolPtr(true)})
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "www", Content: "192.0.2.1", Comment: "duplicate"})
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "TXT", Name: "@", Content: `"v=spf1 include:_spf.example.net ~all"`, Tags: []string{"mail"}})
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "MX", Name: "@", Content: "mail.example.com", Priority: cloudflare.Uint16Ptr(10)})
+
+	dir := t.TempDir()
+	zoneFile := filepath.Join(dir, "example.com.zone")
+	if err := os.WriteFile(zoneFile, []byte("www.example.com. 300 IN A 192.0.2.2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	configFile := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configFile, []byte("api_token: file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	idFile := filepath.Join(dir, "ids")
+	if err := os.WriteFile(idFile, []byte(www.ID+"\nmissing\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := [][]string{
+		{"zones", "list"},
+		{"zones", "list", "--count"},
+		{"zones", "list", "--fields", "name,status,nameservers"},
+		{"zones", "get", "example.com"},
+		{"zones", "get", "example.com", "missing.example"},
+		{"zones", "get", "example.com", "--records"},
+		{"dns", "list", "example.com"},
+		{"dns", "list", "example.com", "--count"},
+		{"dns", "list", "example.com", "--group-by", "type"},
+		{"dns", "list", "example.com", "--duplicates"},
+		{"dns", "list", "example.com", "--expand-txt"},
+		{"dns", "get", "example.com", www.ID},
+		{"dns", "get", "example.com", "--record-id-file", idFile},
+		{"dns", "find", "example.com", "--name", "www", "--type", "A"},
+		{"dns", "exists", "example.com", "--name", "www", "--type", "A"},
+		{"dns", "audit", "example.com"},
+		{"dns", "diff", "example.com", zoneFile},
+		{"dns", "export", "example.com", "--dir", filepath.Join(dir, "split"), "--split-by-type"},
+		{"dns", "export-all", "--dir", filepath.Join(dir, "all")},
+		{"dns", "create", "example.com", "--type", "A", "--name", "api", "--content", "192.0.2.3", "--dry-run"},
+		{"dns", "create", "example.com", "--type", "A", "--name", "api", "--content", "192.0.2.3"},
+		{"dns", "update", "example.com", www.ID, "--content", "192.0.2.4"},
+		{"dns", "delete", "example.com", www.ID},
+		{"config", "migrate", "--dry-run", "--config", configFile},
+		{"config", "validate", "--config", configFile},
+		{"auth", "list"},
+	}
+	for _, args := range tests {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			stdout, stderr, err := runCmd(t, api, append(args, "-o", "json", "--strict-json")...)
+			if err != nil && strings.Contains(err.Error(), "strict JSON") {
+				t.Fatalf("%v\n%s", err, stderr)
+			}
+			if strings.TrimSpace(stdout) == "" {
+				t.Fatalf("no JSON output (error: %v)\n%s", err, stderr)
+			}
+		})
+	}
+}
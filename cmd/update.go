@@ -3,24 +3,70 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/coollabsio/cloudflare-cli/internal/client"
 	"github.com/coollabsio/cloudflare-cli/internal/version"
 	"github.com/creativeprojects/go-selfupdate"
 	goversion "github.com/hashicorp/go-version"
 	"github.com/spf13/cobra"
 )
 
+var updateCheck bool
+
+// updateResult is the JSON result of cf update: whether an update is available
+// (--check) or was installed, and the versions involved
+type updateResult struct {
+	UpdateAvailable *bool  `json:"update_available,omitempty"`
+	Updated         *bool  `json:"updated,omitempty"`
+	Current         string `json:"current,omitempty"`
+	Latest          string `json:"latest,omitempty"`
+	From            string `json:"from,omitempty"`
+	To              string `json:"to,omitempty"`
+}
+
 var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update cf to the latest version",
-	Long:  `Check for and download the latest version of cf from GitHub releases.`,
+	Long: `Check for and download the latest version of cf from GitHub releases.
+
+The downloaded archive is checked against the release's checksums.txt before
+the binary is replaced. A failed download or checksum mismatch is retried with
+backoff (max_retries times, none with --no-retry); if every attempt fails the installed binary is
+left untouched.
+
+After a successful update, the release notes of the new version are printed
+(truncated) along with a link to the full changelog.
+
+Use --check to only report whether a newer version is available. With
+-o json the result is printed as JSON, e.g.
+{"updated":true,"from":"1.2.0","to":"1.3.0"}, and progress goes to stderr.
+
+Examples:
+  cf update
+  cf update --check -o json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		// Keep stdout for the JSON result
+		var progress io.Writer = os.Stdout
+		if outputFormat == "json" {
+			progress = os.Stderr
+		}
+
 		currentVersion := version.GetVersion()
-		fmt.Printf("Current version: %s\n", currentVersion)
-		fmt.Println("Checking for updates...")
+		fmt.Fprintf(progress, "Current version: %s\n", currentVersion)
+		fmt.Fprintln(progress, "Checking for updates...")
 
 		ctx := context.Background()
-		latest, found, err := selfupdate.DetectLatest(ctx, selfupdate.ParseSlug("coollabsio/cloudflare-cli"))
+		updater, err := selfupdate.NewUpdater(selfupdate.Config{
+			Validator: &selfupdate.ChecksumValidator{UniqueFilename: releaseChecksumsFile},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set up updater: %w", err)
+		}
+		latest, found, err := updater.DetectLatest(ctx, selfupdate.ParseSlug("coollabsio/cloudflare-cli"))
 		if err != nil {
 			return fmt.Errorf("failed to detect latest version: %w", err)
 		}
@@ -41,27 +87,102 @@ var updateCmd = &cobra.Command{
 			}
 
 			if !latestVersion.GreaterThan(current) {
+				if outputFormat == "json" {
+					if updateCheck {
+						return out.WriteJSON(updateResult{UpdateAvailable: new(bool), Current: currentVersion, Latest: latest.Version()})
+					}
+					return out.WriteJSON(updateResult{Updated: new(bool), Current: currentVersion})
+				}
 				fmt.Printf("You are already on the latest version (%s)\n", currentVersion)
 				return nil
 			}
 		}
 
-		fmt.Printf("Updating to version %s...\n", latest.Version())
+		if updateCheck {
+			if outputFormat == "json" {
+				available := true
+				return out.WriteJSON(updateResult{UpdateAvailable: &available, Current: currentVersion, Latest: latest.Version()})
+			}
+			fmt.Printf("A new version is available: %s (run 'cf update' to install it)\n", latest.Version())
+			return nil
+		}
+
+		fmt.Fprintf(progress, "Updating to version %s...\n", latest.Version())
 
 		exe, err := selfupdate.ExecutablePath()
 		if err != nil {
 			return fmt.Errorf("failed to get executable path: %w", err)
 		}
 
-		if err := selfupdate.UpdateTo(ctx, latest.AssetURL, latest.AssetName, exe); err != nil {
-			return fmt.Errorf("failed to update: %w", err)
+		retries := configMaxRetries()
+		if cfg.NoRetry {
+			retries = 0
+		}
+		cmd.SilenceUsage = true
+		if err := updateWithRetry(ctx, updater, latest, exe, retries, progress); err != nil {
+			return err
 		}
 
+		if outputFormat == "json" {
+			updated := true
+			return out.WriteJSON(updateResult{Updated: &updated, From: currentVersion, To: latest.Version()})
+		}
 		fmt.Printf("Successfully updated to version %s\n", latest.Version())
+		printReleaseNotes(latest)
 		return nil
 	},
 }
 
+// releaseChecksumsFile is the checksum file goreleaser publishes with each release
+const releaseChecksumsFile = "checksums.txt"
+
+// updateWithRetry downloads, verifies, and installs the release, retrying
+// failed attempts with the API client's backoff. The executable is only
+// replaced after a download passed validation, so a failure leaves it as it was.
+// Retries are reported to progress.
+func updateWithRetry(ctx context.Context, updater *selfupdate.Updater, release *selfupdate.Release, exe string, maxRetries int, progress io.Writer) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = updater.UpdateTo(ctx, release, exe); err == nil {
+			return nil
+		}
+		if attempt >= maxRetries {
+			break
+		}
+		delay := client.BackoffDelay(attempt)
+		fmt.Fprintf(progress, "Download failed (%v), retrying in %s (attempt %d of %d)...\n", err, delay, attempt+2, maxRetries+1)
+		time.Sleep(delay)
+	}
+	return fmt.Errorf("failed to update after %d attempt(s), the installed binary was left unchanged: %w", maxRetries+1, err)
+}
+
+// releaseNotesMaxLines caps how much of the release notes is printed after an update
+const releaseNotesMaxLines = 20
+
+// printReleaseNotes prints the notes of the installed release, truncated,
+// followed by links to the release and the full changelog
+func printReleaseNotes(release *selfupdate.Release) {
+	notes := strings.TrimSpace(release.ReleaseNotes)
+	if notes != "" {
+		lines := strings.Split(notes, "\n")
+		fmt.Printf("\nWhat's new in %s:\n\n", release.Version())
+		for i, line := range lines {
+			if i == releaseNotesMaxLines {
+				fmt.Printf("  ... (%d more lines)\n", len(lines)-releaseNotesMaxLines)
+				break
+			}
+			fmt.Printf("  %s\n", strings.TrimRight(line, "\r"))
+		}
+	}
+
+	fmt.Println()
+	if release.URL != "" {
+		fmt.Printf("Release notes: %s\n", release.URL)
+	}
+	fmt.Println("Full changelog: https://github.com/coollabsio/cloudflare-cli/releases")
+}
+
 func init() {
+	updateCmd.Flags().BoolVar(&updateCheck, "check", false, "only report whether a newer version is available")
 	rootCmd.AddCommand(updateCmd)
 }
@@ -2,12 +2,30 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/coollabsio/cloudflare-cli/internal/client"
 	"github.com/coollabsio/cloudflare-cli/internal/output"
+	"github.com/coollabsio/cloudflare-cli/internal/resolver"
 	"github.com/spf13/cobra"
 )
 
+var (
+	// listCount and listOutputIDs are shared by the zones and dns list commands
+	listCount        bool
+	listOutputIDs    bool
+	zonesGetRecords  bool
+	zonesGetNSDiff   bool
+	zonesListMine    bool
+	zonesListRole    string
+	zonesListAccount string
+	zonesListFields  string
+)
+
 var zonesCmd = &cobra.Command{
 	Use:   "zones",
 	Short: "Zone management commands",
@@ -21,8 +39,38 @@ var zonesListCmd = &cobra.Command{
 Note: If your API token is scoped to specific zones, you may get a permission error.
 In that case, you'll need to either:
   1. Use the zone ID directly with other commands
-  2. Grant your token "All zones" read permission`,
+  2. Grant your token "All zones" read permission
+
+Use --count to print only the number of zones, or --output-ids to print only
+their IDs, one per line (e.g. for xargs).
+
+Each zone is shown with the account it belongs to. Use --mine to list only
+zones in accounts you are an accepted member of, or --role to list only zones
+in accounts where your membership has the given role. Both need user-level
+access to your memberships (an API key, or a token with "Memberships Read").
+Use --account to list only the zones of one account, given by name or ID.
+
+Use --fields to choose the columns, as a comma-separated list of:
+  id, name, status, account, account_id, plan, nameservers,
+  original_nameservers, created, modified
+With -o json, --fields prints each zone as an object with just those fields.
+
+Examples:
+  cf zones list
+  cf zones list --account "Acme Corp"
+  cf zones list --mine
+  cf zones list --output-ids | xargs -n1 cf dns export
+  cf zones list --role "Administrator"
+  cf zones list --fields name,plan,nameservers`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		var fields []zoneField
+		if zonesListFields != "" {
+			var err error
+			if fields, err = parseZoneFields(zonesListFields); err != nil {
+				return err
+			}
+		}
+
 		c, err := client.New(cfg)
 		if err != nil {
 			return err
@@ -34,67 +82,519 @@ In that case, you'll need to either:
 			return err
 		}
 
+		if zonesListAccount != "" {
+			accountID, err := resolveAccount(c, ctx, zonesListAccount)
+			if err != nil {
+				return err
+			}
+			zones = slices.DeleteFunc(zones, func(z client.Zone) bool { return z.AccountID != accountID })
+		}
+
+		if zonesListMine || zonesListRole != "" {
+			zones, err = filterZonesByMembership(ctx, c, zones)
+			if err != nil {
+				return err
+			}
+		}
+
+		if listCount {
+			return writeCount(len(zones))
+		}
+		if listOutputIDs {
+			var ids []string
+			for _, z := range zones {
+				ids = append(ids, z.ID)
+			}
+			writeIDs(ids)
+			return nil
+		}
+
 		if len(zones) == 0 {
 			out.WriteSuccess("No zones found")
 			return nil
 		}
 
-		headers := []string{"ID", "Name", "Status"}
-		var rows [][]string
-		for _, z := range zones {
-			rows = append(rows, []string{z.ID, z.Name, z.Status})
+		if out.IsTemplate() {
+			return out.WriteTemplate(zones)
+		}
+		if fields != nil {
+			return writeZoneFields(zones, fields)
 		}
 
-		return out.WriteTable(headers, rows)
+		return writeZoneTable(zones)
 	},
 }
 
+// filterZonesByMembership keeps the zones whose account the user is an accepted
+// member of, with the --role role if one was given
+func filterZonesByMembership(ctx context.Context, c *client.Client, zones []client.Zone) ([]client.Zone, error) {
+	memberships, err := c.ListMemberships(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make(map[string]bool)
+	for _, m := range memberships {
+		if m.Status != "accepted" {
+			continue
+		}
+		if zonesListRole != "" && !slices.ContainsFunc(m.Roles, func(r string) bool { return strings.EqualFold(r, zonesListRole) }) {
+			continue
+		}
+		accounts[m.AccountID] = true
+	}
+
+	var result []client.Zone
+	for _, z := range zones {
+		if accounts[z.AccountID] {
+			result = append(result, z)
+		}
+	}
+	return result, nil
+}
+
 var zonesGetCmd = &cobra.Command{
-	Use:   "get <zone-name-or-id>",
+	Use:   "get <zone-name-or-id>...",
 	Short: "Get zone details",
-	Long: `Get details for a specific zone by name or ID.
+	Long: `Get details for one or more zones by name or ID.
+
+With several zones, the details are shown as one table (a JSON array with
+-o json). A zone that cannot be fetched is reported in its row and does not
+stop the others; the command exits non-zero if any zone failed.
+
+Use --records to also list the zone's DNS records (optionally filtered with
+--type and --name). --records works with a single zone only.
+
+Use --show-ns-diff to compare the nameservers Cloudflare assigned to the zone
+with the nameservers the parent zone (e.g. .com) actually delegates to, as
+set at the registrar. A zone stays pending until they match. --show-ns-diff
+works with a single zone only; templates get the comparison as .NSDiff.
 
 Examples:
   cf zones get example.com
   cf zones get 023e105f4ecef8ad9ca31a8372d0c353
+  cf zones get example.com example.org 023e105f4ecef8ad9ca31a8372d0c353
+  cf zones get example.com --records --type A
+  cf zones get example.com --show-ns-diff
 
 Note: Looking up zones by name requires the "zone:list" permission.
 If you have a zone-specific token, use the zone ID directly.`,
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 && zonesGetRecords {
+			return fmt.Errorf("--records can only be used with a single zone")
+		}
+		if len(args) > 1 && zonesGetNSDiff {
+			return fmt.Errorf("--show-ns-diff can only be used with a single zone")
+		}
+
 		c, err := client.New(cfg)
 		if err != nil {
 			return err
 		}
 
 		ctx := context.Background()
+		if len(args) > 1 {
+			// Per-zone failures are reported in the output, not as a usage problem
+			cmd.SilenceUsage = true
+			return getZones(ctx, c, args)
+		}
 		zone, err := c.GetZone(ctx, args[0])
 		if err != nil {
 			return err
 		}
 
+		var records []client.DNSRecord
+		if zonesGetRecords {
+			records, err = c.ListDNSRecords(ctx, zone.ID, dnsType, recordName(zone.Name))
+			if err != nil {
+				return err
+			}
+		}
+
+		var nsDiff *zoneNSDiff
+		if zonesGetNSDiff {
+			if nsDiff, err = diffZoneNameServers(ctx, zone); err != nil {
+				return err
+			}
+		}
+
+		if zonesGetRecords && records == nil {
+			records = []client.DNSRecord{}
+		}
+		var result interface{} = zone
+		switch {
+		case zonesGetRecords:
+			result = zoneDetails{zone, records, nsDiff}
+		case nsDiff != nil:
+			result = zoneNSCheck{zone, nsDiff}
+		}
 		if outputFormat == "json" {
-			return out.WriteJSON(zone)
+			return out.WriteJSON(result)
+		}
+		out.TeeJSON(result)
+		if out.IsTemplate() {
+			return out.WriteTemplate(zoneDetails{zone, records, nsDiff})
 		}
 
+		out.SetEnvPrefix("CF_ZONE")
 		headers := []string{"ID", "Name", "Status"}
 		rows := [][]string{{zone.ID, zone.Name, zone.Status}}
-		return out.WriteTable(headers, rows)
+		if err := out.WriteTable(headers, rows); err != nil {
+			return err
+		}
+
+		// Env output has the zone's variables only: the nameserver table has a
+		// row per nameserver
+		if nsDiff != nil && outputFormat != "env" {
+			out.WriteNote("")
+			if err := writeNameServerDiff(nsDiff.Assigned, nsDiff.Delegated); err != nil {
+				return err
+			}
+			if nsDiff.Match {
+				out.WriteNote("\nThe parent zone delegates to the assigned nameservers")
+			} else {
+				out.WriteNote(fmt.Sprintf("\nThe parent zone does not delegate to the assigned nameservers; %s stays pending until the nameservers at your registrar are set to %s", zone.Name, strings.Join(nsDiff.Assigned, ", ")))
+			}
+		}
+
+		if !zonesGetRecords {
+			return nil
+		}
+		out.WriteNote("")
+		if len(records) == 0 {
+			out.WriteSuccess("No DNS records found")
+			return nil
+		}
+		return writeDNSRecordTable(records, "")
+	},
+}
+
+// zoneGetResult is one zone of a multi-zone zones get. Zones that could not be
+// fetched only carry the requested name or ID and the error.
+type zoneGetResult struct {
+	*client.Zone
+	Error string `json:",omitempty"`
+}
+
+// zoneDetails is the result of zones get --records, with the nameserver
+// check if it was asked for too. Templates of zones get always get it.
+type zoneDetails struct {
+	*client.Zone
+	Records []client.DNSRecord
+	NSDiff  *zoneNSDiff `json:",omitempty"`
+}
+
+// zoneNSCheck is the result of zones get with only the nameserver check
+type zoneNSCheck struct {
+	*client.Zone
+	NSDiff *zoneNSDiff
+}
+
+// delegationCheck is the result of zones verify-activation
+type delegationCheck struct {
+	Zone                 string   `json:"zone"`
+	Status               string   `json:"status"`
+	Delegated            bool     `json:"delegated"`
+	Expected             []string `json:"expected"`
+	Observed             []string `json:"observed"`
+	Missing              []string `json:"missing"`
+	Extra                []string `json:"extra"`
+	ActivationCheckError string   `json:"activation_check_error,omitempty"`
+}
+
+// zoneNameServers is the result of zones nameservers. CustomNS is nil when
+// the zone's plan has no custom nameservers.
+type zoneNameServers struct {
+	Zone              string             `json:"zone"`
+	NameServers       []string           `json:"name_servers"`
+	VanityNameServers []string           `json:"vanity_name_servers"`
+	CustomNS          *customNameServers `json:"custom_ns"`
+}
+
+// customNameServers is the account custom nameserver setting of a zone
+type customNameServers struct {
+	Enabled bool `json:"enabled"`
+	NSSet   int  `json:"ns_set"`
+}
+
+// countResult is the JSON form of a bare count
+type countResult struct {
+	Count int `json:"count"`
+}
+
+// zoneFieldRow is one zone of zones list --fields, keyed by field name
+type zoneFieldRow map[string]interface{}
+
+// zoneNSDiff compares the nameservers Cloudflare assigned to a zone with the
+// ones its parent zone delegates to
+type zoneNSDiff struct {
+	Assigned  []string
+	Delegated []string
+	Matched   []string
+	Missing   []string
+	Extra     []string
+	Match     bool
+}
+
+// diffZoneNameServers looks up the delegation of a zone at its parent and
+// compares it with the assigned nameservers
+func diffZoneNameServers(ctx context.Context, zone *client.Zone) (*zoneNSDiff, error) {
+	stop := startSpinner("Looking up delegation...")
+	delegated, err := resolver.LookupParentNS(ctx, zone.Name)
+	stop()
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := make([]string, len(zone.NameServers))
+	for i, ns := range zone.NameServers {
+		assigned[i] = resolver.Normalize(ns)
+	}
+	missing, extra := compareNameServers(assigned, delegated)
+	matched := []string{}
+	for _, ns := range assigned {
+		if !slices.Contains(missing, ns) {
+			matched = append(matched, ns)
+		}
+	}
+	return &zoneNSDiff{
+		Assigned:  assigned,
+		Delegated: nonNil(delegated),
+		Matched:   matched,
+		Missing:   nonNil(missing),
+		Extra:     nonNil(extra),
+		Match:     len(missing) == 0 && len(extra) == 0,
+	}, nil
+}
+
+// getZones fetches several zones, reporting failures per zone
+func getZones(ctx context.Context, c *client.Client, args []string) error {
+	results := make([]zoneGetResult, len(args))
+	failed := 0
+	for i, arg := range args {
+		zone, err := c.GetZone(ctx, arg)
+		if err != nil {
+			results[i] = zoneGetResult{Zone: &client.Zone{Name: arg}, Error: err.Error()}
+			failed++
+			continue
+		}
+		results[i] = zoneGetResult{Zone: zone}
+	}
+
+	if outputFormat == "json" {
+		if err := out.WriteJSON(results); err != nil {
+			return err
+		}
+	} else if out.IsTemplate() {
+		if err := out.WriteTemplate(results); err != nil {
+			return err
+		}
+	} else {
+		out.TeeJSON(results)
+		headers := []string{"ID", "Name", "Status", "Error"}
+		var rows [][]string
+		for _, r := range results {
+			rows = append(rows, []string{r.ID, r.Name, r.Status, r.Error})
+		}
+		if err := out.WriteTable(headers, rows); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d zone(s) could not be fetched", failed, len(args))
+	}
+	return nil
+}
+
+var zonesVerifyActivationCmd = &cobra.Command{
+	Use:   "verify-activation <zone-name-or-id>",
+	Short: "Check a zone's nameserver delegation",
+	Long: `Trigger Cloudflare's activation check for a zone and compare the nameservers
+Cloudflare assigned to the zone against the nameservers actually delegated
+(via a live NS lookup).
+
+Exits with an error if the delegation does not match, so it can be used in scripts.
+
+Examples:
+  cf zones verify-activation example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		zone, err := resolveZoneDetails(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		// The activation check only applies to pending zones and is rate limited,
+		// so a failure here is reported but doesn't stop the comparison.
+		var checkErr error
+		if zone.Status != "active" {
+			checkErr = c.ActivateZone(ctx, zone.ID)
+		}
+
+		observed, err := resolver.LookupNS(ctx, zone.Name)
+		if err != nil {
+			return err
+		}
+
+		expected := make([]string, len(zone.NameServers))
+		for i, ns := range zone.NameServers {
+			expected[i] = resolver.Normalize(ns)
+		}
+		missing, extra := compareNameServers(expected, observed)
+		delegated := len(missing) == 0 && len(extra) == 0
+
+		result := delegationCheck{
+			Zone:      zone.Name,
+			Status:    zone.Status,
+			Delegated: delegated,
+			Expected:  expected,
+			Observed:  observed,
+			Missing:   missing,
+			Extra:     extra,
+		}
+		if checkErr != nil {
+			result.ActivationCheckError = checkErr.Error()
+		}
+		if outputFormat == "json" {
+			if err := out.WriteJSON(result); err != nil {
+				return err
+			}
+		} else {
+			out.TeeJSON(result)
+			if checkErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", checkErr)
+			}
+			if err := writeNameServerDiff(expected, observed); err != nil {
+				return err
+			}
+			out.WriteNote(fmt.Sprintf("\nZone status: %s", zone.Status))
+		}
+
+		if !delegated {
+			return fmt.Errorf("nameserver delegation for %s does not match: set the nameservers at your registrar to %s", zone.Name, strings.Join(expected, ", "))
+		}
+		if outputFormat != "json" {
+			out.WriteSuccess("Nameserver delegation is correct")
+		}
+		return nil
+	},
+}
+
+var zonesNameserversCmd = &cobra.Command{
+	Use:   "nameservers <zone-name-or-id>",
+	Short: "Show a zone's nameservers",
+	Long: `Show the nameservers Cloudflare assigned to a zone, along with any custom
+nameservers: vanity nameservers configured on the zone, and whether account
+custom nameservers are enabled.
+
+Custom nameservers are only available on some plans; when they are not, only
+the assigned nameservers are shown.
+
+Examples:
+  cf zones nameservers example.com
+  cf zones nameservers example.com -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		zone, err := resolveZoneDetails(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Not every plan supports custom nameservers; treat an error as "not available"
+		custom, customErr := c.GetCustomNameserverSettings(ctx, zone.ID)
+
+		result := zoneNameServers{
+			Zone:              zone.Name,
+			NameServers:       nonNil(zone.NameServers),
+			VanityNameServers: nonNil(zone.VanityNameServers),
+		}
+		if customErr == nil {
+			result.CustomNS = &customNameServers{Enabled: custom.Enabled, NSSet: custom.NSSet}
+		}
+		if outputFormat == "json" {
+			return out.WriteJSON(result)
+		}
+		out.TeeJSON(result)
+
+		headers := []string{"Type", "Nameserver"}
+		var rows [][]string
+		for _, ns := range zone.NameServers {
+			rows = append(rows, []string{"assigned", ns})
+		}
+		for _, ns := range zone.VanityNameServers {
+			rows = append(rows, []string{"vanity", ns})
+		}
+		if err := out.WriteTable(headers, rows); err != nil {
+			return err
+		}
+
+		switch {
+		case customErr != nil:
+			out.WriteNote("\nAccount custom nameservers: not available for this zone")
+		case custom.Enabled:
+			out.WriteNote(fmt.Sprintf("\nAccount custom nameservers: enabled (set %d)", custom.NSSet))
+		default:
+			out.WriteNote("\nAccount custom nameservers: disabled")
+		}
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(zonesCmd)
+	zonesListCmd.Flags().BoolVar(&listCount, "count", false, "print only the number of zones")
+	zonesListCmd.Flags().BoolVar(&listOutputIDs, "output-ids", false, "print only zone IDs, one per line")
+	zonesListCmd.Flags().StringVar(&zonesListAccount, "account", "", "only zones in this account (name or ID)")
+	zonesListCmd.Flags().BoolVar(&zonesListMine, "mine", false, "only zones in accounts you are a member of")
+	zonesListCmd.Flags().StringVar(&zonesListRole, "role", "", "only zones in accounts where your membership has this role")
+	zonesListCmd.Flags().StringVar(&zonesListFields, "fields", "", "comma-separated columns to show (e.g. name,plan,nameservers)")
 	zonesCmd.AddCommand(zonesListCmd)
+	zonesGetCmd.Flags().BoolVar(&zonesGetRecords, "records", false, "also list the zone's DNS records")
+	zonesGetCmd.Flags().StringVarP(&dnsType, "type", "t", "", "with --records, filter by record type")
+	zonesGetCmd.Flags().BoolVar(&zonesGetNSDiff, "show-ns-diff", false, "compare the assigned nameservers with the live delegation at the parent zone")
+	zonesGetCmd.Flags().StringVarP(&dnsName, "name", "n", "", "with --records, filter by record name")
+	addApexFlag(zonesGetCmd)
 	zonesCmd.AddCommand(zonesGetCmd)
+	zonesCmd.AddCommand(zonesVerifyActivationCmd)
+	zonesCmd.AddCommand(zonesNameserversCmd)
 }
 
 // resolveZone is a helper to resolve a zone argument to a zone ID
 // It provides helpful error messages for permission issues
 func resolveZone(c *client.Client, ctx context.Context, nameOrID string) (string, error) {
+	stop := startSpinner("Resolving zone...")
+	defer stop()
 	return c.ResolveZoneID(ctx, nameOrID)
 }
 
+// resolveAccount resolves an account argument (name or ID) to an account ID
+func resolveAccount(c *client.Client, ctx context.Context, nameOrID string) (string, error) {
+	stop := startSpinner("Resolving account...")
+	defer stop()
+	return c.ResolveAccountID(ctx, nameOrID)
+}
+
+// resolveZoneDetails resolves a zone argument to the full zone (ID and name)
+func resolveZoneDetails(c *client.Client, ctx context.Context, nameOrID string) (*client.Zone, error) {
+	stop := startSpinner("Resolving zone...")
+	defer stop()
+	return c.GetZone(ctx, nameOrID)
+}
+
 // mustResolveZone resolves a zone and exits on error with formatted output
 func mustResolveZone(c *client.Client, ctx context.Context, nameOrID string) string {
 	zoneID, err := resolveZone(c, ctx, nameOrID)
@@ -104,30 +604,200 @@ func mustResolveZone(c *client.Client, ctx context.Context, nameOrID string) str
 	return zoneID
 }
 
+// writeCount writes a bare count, or {"count": N} in JSON mode
+func writeCount(n int) error {
+	if outputFormat == "json" {
+		return out.WriteJSON(countResult{Count: n})
+	}
+	out.TeeJSON(countResult{Count: n})
+	fmt.Println(n)
+	return nil
+}
+
+// writeIDs prints one ID per line with nothing else, regardless of output format
+func writeIDs(ids []string) {
+	for _, id := range ids {
+		fmt.Println(id)
+	}
+}
+
+// compareNameServers returns the expected nameservers missing from observed,
+// and the observed nameservers that were not expected
+func compareNameServers(expected, observed []string) (missing, extra []string) {
+	seen := make(map[string]bool)
+	for _, ns := range observed {
+		seen[ns] = true
+	}
+	want := make(map[string]bool)
+	for _, ns := range expected {
+		want[ns] = true
+		if !seen[ns] {
+			missing = append(missing, ns)
+		}
+	}
+	for _, ns := range observed {
+		if !want[ns] {
+			extra = append(extra, ns)
+		}
+	}
+	return missing, extra
+}
+
+// writeNameServerDiff writes expected vs observed nameservers as a table
+func writeNameServerDiff(expected, observed []string) error {
+	missing, extra := compareNameServers(expected, observed)
+	isMissing := make(map[string]bool)
+	for _, ns := range missing {
+		isMissing[ns] = true
+	}
+
+	headers := []string{"Nameserver", "Expected", "Observed", "Status"}
+	var rows [][]string
+	for _, ns := range expected {
+		if isMissing[ns] {
+			rows = append(rows, []string{ns, "yes", "no", "missing"})
+		} else {
+			rows = append(rows, []string{ns, "yes", "yes", "ok"})
+		}
+	}
+	for _, ns := range extra {
+		rows = append(rows, []string{ns, "no", "yes", "extra"})
+	}
+	return out.WriteTable(headers, rows)
+}
+
+// nonNil returns s, or an empty slice if s is nil, so it encodes as [] in JSON
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
 // writeZoneTable writes zones in table format
 func writeZoneTable(zones []client.Zone) error {
-	headers := []string{"ID", "Name", "Status"}
+	headers := []string{"ID", "Name", "Status", "Account"}
+	var rows [][]string
+	for _, z := range zones {
+		rows = append(rows, []string{z.ID, z.Name, z.Status, z.AccountName})
+	}
+	return out.WriteTable(headers, rows)
+}
+
+// zoneField is a column of zones list --fields
+type zoneField struct {
+	name   string
+	header string
+	value  func(z client.Zone) interface{}
+}
+
+// zoneFields are the columns zones list --fields can select, in the order
+// listed in its help
+var zoneFields = []zoneField{
+	{"id", "ID", func(z client.Zone) interface{} { return z.ID }},
+	{"name", "Name", func(z client.Zone) interface{} { return z.Name }},
+	{"status", "Status", func(z client.Zone) interface{} { return z.Status }},
+	{"account", "Account", func(z client.Zone) interface{} { return z.AccountName }},
+	{"account_id", "Account ID", func(z client.Zone) interface{} { return z.AccountID }},
+	{"plan", "Plan", func(z client.Zone) interface{} { return z.Plan }},
+	{"nameservers", "Nameservers", func(z client.Zone) interface{} { return nonNil(z.NameServers) }},
+	{"original_nameservers", "Original Nameservers", func(z client.Zone) interface{} { return nonNil(z.OriginalNameServers) }},
+	{"created", "Created", func(z client.Zone) interface{} { return z.CreatedOn }},
+	{"modified", "Modified", func(z client.Zone) interface{} { return z.ModifiedOn }},
+}
+
+// parseZoneFields parses the --fields list of zones list
+func parseZoneFields(s string) ([]zoneField, error) {
+	var fields []zoneField
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		i := slices.IndexFunc(zoneFields, func(f zoneField) bool { return f.name == name })
+		if i < 0 {
+			var names []string
+			for _, f := range zoneFields {
+				names = append(names, f.name)
+			}
+			return nil, fmt.Errorf("unknown field %q (available: %s)", name, strings.Join(names, ", "))
+		}
+		fields = append(fields, zoneFields[i])
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("--fields needs at least one field")
+	}
+	return fields, nil
+}
+
+// writeZoneFields writes zones with the columns chosen by --fields. JSON
+// output keeps each field's type, e.g. nameservers as a list.
+func writeZoneFields(zones []client.Zone, fields []zoneField) error {
+	items := make([]zoneFieldRow, 0, len(zones))
+	for _, z := range zones {
+		item := make(zoneFieldRow)
+		for _, f := range fields {
+			item[f.name] = f.value(z)
+		}
+		items = append(items, item)
+	}
+	if outputFormat == "json" {
+		return out.WriteJSON(items)
+	}
+	out.TeeJSON(items)
+
+	var headers []string
+	for _, f := range fields {
+		headers = append(headers, f.header)
+	}
 	var rows [][]string
 	for _, z := range zones {
-		rows = append(rows, []string{z.ID, z.Name, z.Status})
+		var row []string
+		for _, f := range fields {
+			switch v := f.value(z).(type) {
+			case []string:
+				row = append(row, strings.Join(v, ", "))
+			case time.Time:
+				if v.IsZero() {
+					row = append(row, "")
+				} else {
+					row = append(row, v.UTC().Format(time.RFC3339))
+				}
+			default:
+				row = append(row, fmt.Sprint(v))
+			}
+		}
+		rows = append(rows, row)
 	}
 	return out.WriteTable(headers, rows)
 }
 
-// writeDNSRecordTable writes DNS records in table format
-func writeDNSRecordTable(records []client.DNSRecord) error {
+// writeDNSRecordTable writes DNS records in table format. With dns list
+// --short, names are shown relative to zoneName.
+func writeDNSRecordTable(records []client.DNSRecord, zoneName string) error {
+	if out.IsTemplate() {
+		return out.WriteTemplate(records)
+	}
+
 	headers := []string{"ID", "Type", "Name", "Content", "TTL", "Proxied", "Comment"}
+	if listWide {
+		headers = append(headers, "Proxiable")
+	}
 	var rows [][]string
 	for _, r := range records {
-		rows = append(rows, []string{
+		row := []string{
 			r.ID,
-			r.Type,
-			r.Name,
+			recordTypeLabel(r, zoneName),
+			displayName(r.Name, zoneName),
 			r.Content,
 			output.FormatTTL(r.TTL),
 			output.FormatBool(r.Proxied),
 			r.Comment,
-		})
+		}
+		if listWide {
+			row = append(row, output.FormatBool(r.Proxiable))
+		}
+		rows = append(rows, row)
 	}
 	return out.WriteTable(headers, rows)
 }
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+// accessRuleModes are the modes an IP access rule can have
+var accessRuleModes = []string{"block", "challenge", "js_challenge", "managed_challenge", "whitelist"}
+
+var accessRulesMode string
+
+var zonesAccessRulesCmd = &cobra.Command{
+	Use:   "access-rules",
+	Short: "IP access rule commands",
+}
+
+var zonesAccessRulesListCmd = &cobra.Command{
+	Use:   "list <zone>",
+	Short: "List the IP access rules of a zone",
+	Long: `List the IP access rules that apply to a zone: the mode (block, challenge,
+whitelist, ...), what the rule matches (an IP, range, ASN, or country), its
+notes, and whether it is defined on the zone, account, or user.
+
+Examples:
+  cf zones access-rules list example.com
+  cf zones access-rules list example.com --mode block
+  cf zones access-rules list example.com -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if accessRulesMode != "" && !slices.Contains(accessRuleModes, accessRulesMode) {
+			return fmt.Errorf("invalid --mode: %s (must be one of %s)", accessRulesMode, strings.Join(accessRuleModes, ", "))
+		}
+
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		zoneID, err := resolveZone(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		rules, err := c.ListAccessRules(ctx, zoneID, accessRulesMode)
+		if err != nil {
+			return err
+		}
+
+		if rules == nil {
+			rules = []client.AccessRule{}
+		}
+		if outputFormat == "json" {
+			return out.WriteJSON(rules)
+		}
+		out.TeeJSON(rules)
+		if out.IsTemplate() {
+			return out.WriteTemplate(rules)
+		}
+		if len(rules) == 0 {
+			out.WriteSuccess("No access rules found")
+			return nil
+		}
+
+		headers := []string{"ID", "Mode", "Target", "Value", "Scope", "Notes"}
+		var rows [][]string
+		for _, r := range rules {
+			rows = append(rows, []string{r.ID, r.Mode, r.Target, r.Value, r.Scope, r.Notes})
+		}
+		return out.WriteTable(headers, rows)
+	},
+}
+
+func init() {
+	zonesAccessRulesListCmd.Flags().StringVar(&accessRulesMode, "mode", "", "only rules with this mode (block, challenge, js_challenge, managed_challenge, whitelist)")
+	zonesAccessRulesCmd.AddCommand(zonesAccessRulesListCmd)
+	zonesCmd.AddCommand(zonesAccessRulesCmd)
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	zonesCreateAccount  string
+	zonesCreateFromFile string
+)
+
+// zoneCreateResult is the outcome of creating a single zone
+type zoneCreateResult struct {
+	Zone        string   `json:"zone"`
+	ID          string   `json:"id,omitempty"`
+	Status      string   `json:"status,omitempty"`
+	NameServers []string `json:"name_servers,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+var zonesCreateCmd = &cobra.Command{
+	Use:   "create [domain]...",
+	Short: "Add one or more zones to an account",
+	Long: `Add zones to an account and show the nameservers Cloudflare assigned to each,
+which have to be set at the registrar to activate the zone.
+
+Domains are given as arguments or read from a file with --from-file (one
+domain per line; blank lines and lines starting with # are ignored). The
+account is given by name or ID with --account.
+
+By default a failure for one domain does not stop the others and failures are
+reported at the end; use --fail-fast to stop at the first failure.
+
+Examples:
+  cf zones create example.com --account "Acme Corp"
+  cf zones create --from-file domains.txt --account 023e105f4ecef8ad9ca31a8372d0c353
+  cf zones create --from-file domains.txt --account "Acme Corp" -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var domains []string
+		for _, arg := range args {
+			domains = append(domains, strings.ToLower(strings.TrimSuffix(arg, ".")))
+		}
+		if zonesCreateFromFile != "" {
+			fromFile, err := readDomainsFile(zonesCreateFromFile)
+			if err != nil {
+				return err
+			}
+			domains = append(domains, fromFile...)
+		}
+		if len(domains) == 0 {
+			return fmt.Errorf("no domains given (pass them as arguments or with --from-file)")
+		}
+
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		accountID, err := resolveAccount(c, ctx, zonesCreateAccount)
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+		var results []zoneCreateResult
+		failed := 0
+		for _, domain := range domains {
+			if stopAfterFailure(failed) {
+				results = append(results, zoneCreateResult{Zone: domain, Error: skippedAfterFailure})
+				continue
+			}
+			stop := startSpinner(fmt.Sprintf("Creating %s...", domain))
+			result := createZone(ctx, c, domain, accountID)
+			stop()
+			if result.Error != "" {
+				failed++
+			}
+			results = append(results, result)
+		}
+
+		shown := onlyFailed(results, func(r zoneCreateResult) bool { return r.Error != "" })
+		if outputFormat == "json" {
+			if err := out.WriteJSON(shown); err != nil {
+				return err
+			}
+		} else {
+			out.TeeJSON(shown)
+			headers := []string{"Zone", "ID", "Status", "Name Servers", "Error"}
+			var rows [][]string
+			for _, r := range results {
+				rows = append(rows, []string{r.Zone, r.ID, r.Status, strings.Join(r.NameServers, ", "), r.Error})
+			}
+			if err := writeBulkResults(headers, rows); err != nil {
+				return err
+			}
+			if c.DryRun() {
+				out.WriteNote(fmt.Sprintf("\n(dry-run) Would create %d zone(s)", len(domains)))
+			} else {
+				out.WriteNote(fmt.Sprintf("\n%d of %d zone(s) created, %d failed", len(domains)-failed, len(domains), failed))
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d zone(s) failed to create", failed, len(domains))
+		}
+		return nil
+	},
+}
+
+func init() {
+	zonesCreateCmd.Flags().StringVar(&zonesCreateAccount, "account", "", "account to add the zones to (name or ID)")
+	zonesCreateCmd.Flags().StringVar(&zonesCreateFromFile, "from-file", "", "read domains from this file, one per line")
+	zonesCreateCmd.MarkFlagRequired("account")
+	addBulkErrorFlags(zonesCreateCmd)
+	zonesCmd.AddCommand(zonesCreateCmd)
+}
+
+// createZone creates one zone and records the outcome; it is shared by single
+// and file-driven zone creation
+func createZone(ctx context.Context, c *client.Client, domain, accountID string) zoneCreateResult {
+	result := zoneCreateResult{Zone: domain}
+	zone, err := c.CreateZone(ctx, domain, accountID)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.ID = zone.ID
+	result.Status = zone.Status
+	result.NameServers = zone.NameServers
+	return result
+}
+
+// readDomainsFile reads domains, one per line, skipping blank lines and lines
+// starting with #. Trailing dots are removed.
+func readDomainsFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read domains file: %w", err)
+	}
+
+	var domains []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, strings.ToLower(strings.TrimSuffix(line, ".")))
+	}
+	if len(domains) == 0 {
+		return nil, fmt.Errorf("domains file %s does not list any domains", path)
+	}
+	return domains, nil
+}
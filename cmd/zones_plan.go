@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/coollabsio/cloudflare-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var zonesPlanYes bool
+
+// zonePlans is the result of zones plan get
+type zonePlans struct {
+	Zone  string            `json:"zone"`
+	Plans []client.ZonePlan `json:"plans"`
+}
+
+var zonesPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show or change a zone's subscription plan",
+}
+
+var zonesPlanGetCmd = &cobra.Command{
+	Use:   "get <zone>",
+	Short: "Show the current plan and the plans available to a zone",
+	Long: `Show the plan a zone is subscribed to and every plan available to it, with
+its price and whether the zone can subscribe to it. Use a plan ID from this
+list with "cf zones plan set".
+
+Examples:
+  cf zones plan get example.com
+  cf zones plan get example.com -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		zone, err := resolveZoneDetails(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		plans, err := c.ListZonePlans(ctx, zone.ID)
+		if err != nil {
+			return err
+		}
+
+		if plans == nil {
+			plans = []client.ZonePlan{}
+		}
+		result := zonePlans{Zone: zone.Name, Plans: plans}
+		if outputFormat == "json" {
+			return out.WriteJSON(result)
+		}
+		out.TeeJSON(result)
+
+		headers := []string{"ID", "Name", "Price", "Current", "Available"}
+		var rows [][]string
+		for _, p := range plans {
+			rows = append(rows, []string{
+				p.ID,
+				p.Name,
+				formatPlanPrice(p),
+				output.FormatBool(p.IsSubscribed),
+				output.FormatBool(p.CanSubscribe),
+			})
+		}
+		return out.WriteTable(headers, rows)
+	},
+}
+
+var zonesPlanSetCmd = &cobra.Command{
+	Use:   "set <zone> <plan-id>",
+	Short: "Change a zone's subscription plan",
+	Long: `Subscribe a zone to another plan. Changing the plan can incur charges on the
+account, so it asks for confirmation first; use --yes to skip the prompt.
+Plan IDs are listed by "cf zones plan get".
+
+Examples:
+  cf zones plan set example.com 94f3b7b768b0458b56d2cac4fe5ec0f9
+  cf zones plan set example.com 94f3b7b768b0458b56d2cac4fe5ec0f9 --yes`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		zone, err := resolveZoneDetails(c, ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		plans, err := c.ListZonePlans(ctx, zone.ID)
+		if err != nil {
+			return err
+		}
+		var plan *client.ZonePlan
+		for i := range plans {
+			if plans[i].ID == args[1] {
+				plan = &plans[i]
+				break
+			}
+		}
+		if plan == nil {
+			return fmt.Errorf("plan %s is not available to %s (see \"cf zones plan get %s\")", args[1], zone.Name, zone.Name)
+		}
+		if plan.IsSubscribed {
+			out.WriteSuccess(fmt.Sprintf("%s is already on the %s plan", zone.Name, plan.Name))
+			return nil
+		}
+		if !plan.CanSubscribe {
+			return fmt.Errorf("%s cannot subscribe to the %s plan", zone.Name, plan.Name)
+		}
+
+		prompt := fmt.Sprintf("Change %s to the %s plan (%s)? This may incur charges.", zone.Name, plan.Name, formatPlanPrice(*plan))
+		if !zonesPlanYes && !c.DryRun() && !confirm(prompt) {
+			return fmt.Errorf("aborted: plan left unchanged (use --yes to skip confirmation)")
+		}
+
+		if err := c.SetZonePlan(ctx, zone.ID, plan.ID); err != nil {
+			return err
+		}
+		if c.DryRun() {
+			out.WriteSuccess(fmt.Sprintf("(dry-run) Would change %s to the %s plan", zone.Name, plan.Name))
+			return nil
+		}
+		out.WriteSuccess(fmt.Sprintf("%s changed to the %s plan", zone.Name, plan.Name))
+		return nil
+	},
+}
+
+func init() {
+	zonesPlanSetCmd.Flags().BoolVarP(&zonesPlanYes, "yes", "y", false, "skip the confirmation prompt")
+	zonesPlanCmd.AddCommand(zonesPlanGetCmd)
+	zonesPlanCmd.AddCommand(zonesPlanSetCmd)
+	zonesCmd.AddCommand(zonesPlanCmd)
+}
+
+// formatPlanPrice formats a plan's price, e.g. "20 USD/monthly" or "free"
+func formatPlanPrice(p client.ZonePlan) string {
+	if p.Price == 0 {
+		return "free"
+	}
+	price := fmt.Sprintf("%d %s", p.Price, strings.ToUpper(p.Currency))
+	if p.Frequency != "" {
+		price += "/" + p.Frequency
+	}
+	return price
+}
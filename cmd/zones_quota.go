@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var zonesQuotaAccount string
+
+var zonesQuotaCmd = &cobra.Command{
+	Use:   "quota",
+	Short: "Show zone counts per account against subscription limits",
+	Long: `Show how many zones each account has (and how many are active or pending),
+next to the zone limit of the account's subscriptions when Cloudflare reports
+one. Reading the limit needs billing read access; without it the limit is
+shown as "not reported".
+
+Examples:
+  cf zones quota
+  cf zones quota --account "Acme Corp"
+  cf zones quota -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		stop := startSpinner("Counting zones...")
+		usage, err := c.ZoneUsage(ctx)
+		stop()
+		if err != nil {
+			return err
+		}
+
+		if zonesQuotaAccount != "" {
+			accountID, err := resolveAccount(c, ctx, zonesQuotaAccount)
+			if err != nil {
+				return err
+			}
+			usage = slices.DeleteFunc(usage, func(u client.ZoneUsage) bool { return u.AccountID != accountID })
+			if len(usage) == 0 {
+				usage = []client.ZoneUsage{{AccountID: accountID}}
+			}
+		}
+
+		if usage == nil {
+			usage = []client.ZoneUsage{}
+		}
+		if outputFormat == "json" {
+			return out.WriteJSON(usage)
+		}
+		out.TeeJSON(usage)
+		if len(usage) == 0 {
+			out.WriteSuccess("No zones found")
+			return nil
+		}
+
+		for i, u := range usage {
+			if i > 0 {
+				out.WriteNote("")
+			}
+			limit, remaining := "not reported", "-"
+			if u.Limit != nil {
+				limit = strconv.Itoa(*u.Limit)
+				remaining = strconv.Itoa(*u.Limit - u.Zones)
+			}
+			headers := []string{"Key", "Value"}
+			rows := [][]string{
+				{"account", u.AccountName},
+				{"account_id", u.AccountID},
+				{"zones", strconv.Itoa(u.Zones)},
+				{"active", strconv.Itoa(u.Active)},
+				{"pending", strconv.Itoa(u.Pending)},
+				{"limit", limit},
+				{"remaining", remaining},
+			}
+			if err := out.WriteTable(headers, rows); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	zonesQuotaCmd.Flags().StringVar(&zonesQuotaAccount, "account", "", "only this account (name or ID)")
+	zonesCmd.AddCommand(zonesQuotaCmd)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+func TestZonesGetRecordsOutput(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+	api.addRecord("example.com", cloudflare.DNSRecord{Type: "A", Name: "www", Content: "192.0.2.1"})
+
+	stdout, _, err := runCmd(t, api, "zones", "get", "example.com", "--records")
+	if err != nil || !strings.Contains(stdout, "\n\nID") || !strings.Contains(stdout, "www.example.com") {
+		t.Errorf("table output: %v, want the zone and records tables apart:\n%s", err, stdout)
+	}
+
+	stdout, _, err = runCmd(t, api, "zones", "get", "example.com", "--records", "-o", "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		if !strings.HasPrefix(line, "CF_") {
+			t.Errorf("env output has a line that is not an assignment: %q", line)
+		}
+	}
+}
+
+func TestZonesNameserversFooter(t *testing.T) {
+	api := newMockAPI(t, "example.com")
+
+	stdout, _, err := runCmd(t, api, "zones", "nameservers", "example.com")
+	if err != nil || !strings.Contains(stdout, "Account custom nameservers: not available") {
+		t.Errorf("table output: %v, want the custom nameserver footer:\n%s", err, stdout)
+	}
+
+	stdout, _, err = runCmd(t, api, "zones", "nameservers", "example.com", "--template", "{{.Nameserver}}")
+	if err != nil || strings.Contains(stdout, "Account custom nameservers") {
+		t.Errorf("template output: %v, want only the nameservers:\n%s", err, stdout)
+	}
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coollabsio/cloudflare-cli/internal/config"
+)
+
+func TestGetZoneAnalyticsUniques(t *testing.T) {
+	group := func(requests, uniques int64) map[string]interface{} {
+		return map[string]interface{}{
+			"sum":  map[string]int64{"requests": requests},
+			"uniq": map[string]int64{"uniques": uniques},
+		}
+	}
+	tests := []struct {
+		name         string
+		groups       []interface{}
+		wantRequests int64
+		wantUniques  *int64
+	}{
+		{"one group", []interface{}{group(10, 4)}, 10, ptr(int64(4))},
+		{"several groups", []interface{}{group(10, 4), group(5, 3)}, 15, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"data": map[string]interface{}{"viewer": map[string]interface{}{
+						"zones": []interface{}{map[string]interface{}{"groups": tt.groups}},
+					}},
+				})
+			}))
+			defer server.Close()
+			c, err := New(&config.Config{APIToken: "test-token", APIBaseURL: server.URL})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			until := time.Now()
+			a, err := c.GetZoneAnalytics(context.Background(), testZoneID, until.Add(-2*time.Hour), until)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a.Requests == nil || *a.Requests != tt.wantRequests {
+				t.Errorf("requests = %v, want %d", a.Requests, tt.wantRequests)
+			}
+			switch {
+			case tt.wantUniques == nil && a.Uniques != nil:
+				t.Errorf("uniques = %d, want none (uniques of several groups can't be summed)", *a.Uniques)
+			case tt.wantUniques != nil && (a.Uniques == nil || *a.Uniques != *tt.wantUniques):
+				t.Errorf("uniques = %v, want %d", a.Uniques, *tt.wantUniques)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
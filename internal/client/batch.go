@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/coollabsio/cloudflare-cli/internal/logging"
+)
+
+// BatchThreshold is the number of queued operations above which bulk commands
+// send them through BatchDNSRecords instead of one request per record
+const BatchThreshold = 10
+
+// maxBatchSize is the largest number of operations sent in one batch request
+const maxBatchSize = 200
+
+// BatchPatch is a single record update in a batch
+type BatchPatch struct {
+	ID     string
+	Params UpdateDNSRecordParams
+}
+
+// BatchDNSRecordsParams contains the operations of a batch. Cloudflare applies
+// them in the order deletes, patches, posts.
+type BatchDNSRecordsParams struct {
+	Deletes []string
+	Patches []BatchPatch
+	Posts   []CreateDNSRecordParams
+}
+
+// BatchDNSRecordsResult holds the records returned for each operation, in request order
+type BatchDNSRecordsResult struct {
+	Deletes []DNSRecord
+	Patches []DNSRecord
+	Posts   []DNSRecord
+}
+
+// batchID is a record reference in a batch request
+type batchID struct {
+	ID string `json:"id"`
+}
+
+// batchRecord is a created or patched record in a batch request
+type batchRecord struct {
+	ID       string                 `json:"id,omitempty"`
+	Type     string                 `json:"type,omitempty"`
+	Name     string                 `json:"name,omitempty"`
+	Content  string                 `json:"content,omitempty"`
+	TTL      int                    `json:"ttl,omitempty"`
+	Proxied  *bool                  `json:"proxied,omitempty"`
+	Priority *uint16                `json:"priority,omitempty"`
+	Comment  *string                `json:"comment,omitempty"`
+	Tags     []string               `json:"tags"`
+	Data     map[string]interface{} `json:"data,omitempty"`
+}
+
+// batchRequest is the body of a batch request
+type batchRequest struct {
+	Deletes []batchID     `json:"deletes,omitempty"`
+	Patches []batchRecord `json:"patches,omitempty"`
+	Posts   []batchRecord `json:"posts,omitempty"`
+}
+
+// batchResponse is the result of a batch request
+type batchResponse struct {
+	Deletes []cloudflare.DNSRecord `json:"deletes"`
+	Patches []cloudflare.DNSRecord `json:"patches"`
+	Posts   []cloudflare.DNSRecord `json:"posts"`
+}
+
+// BatchDNSRecords applies many record changes through Cloudflare's batch DNS
+// endpoint. Operations are sent in requests of up to maxBatchSize; each request
+// either succeeds or fails as a whole, so on error the result holds only the
+// operations from requests that were applied.
+func (c *Client) BatchDNSRecords(ctx context.Context, zoneID string, params BatchDNSRecordsParams) (*BatchDNSRecordsResult, error) {
+	if c.dryRun {
+		logging.Logger.Info("dns record batch skipped (dry-run)", "zone_id", zoneID, "deletes", len(params.Deletes), "patches", len(params.Patches), "posts", len(params.Posts))
+		for _, req := range splitBatch(params) {
+			c.describeSkipped(http.MethodPost, fmt.Sprintf("/zones/%s/dns_records/batch", zoneID), req)
+		}
+		return simulateBatch(params), nil
+	}
+
+	result := &BatchDNSRecordsResult{}
+	for _, req := range splitBatch(params) {
+		raw, err := c.api.Raw(ctx, http.MethodPost, fmt.Sprintf("/zones/%s/dns_records/batch", zoneID), req, nil)
+		if err != nil {
+			logging.Logger.Error("dns record batch failed", "zone_id", zoneID, "operations", len(req.Deletes)+len(req.Patches)+len(req.Posts), "error", err)
+			return result, fmt.Errorf("failed to apply DNS record batch: %w", translateError(err))
+		}
+
+		var resp batchResponse
+		if err := json.Unmarshal(raw.Result, &resp); err != nil {
+			return result, fmt.Errorf("failed to decode DNS record batch response: %w", err)
+		}
+		for _, r := range resp.Deletes {
+			result.Deletes = append(result.Deletes, recordFromAPI(r))
+		}
+		for _, r := range resp.Patches {
+			result.Patches = append(result.Patches, recordFromAPI(r))
+		}
+		for _, r := range resp.Posts {
+			result.Posts = append(result.Posts, recordFromAPI(r))
+		}
+		logging.Logger.Info("dns record batch applied", "zone_id", zoneID, "deletes", len(resp.Deletes), "patches", len(resp.Patches), "posts", len(resp.Posts))
+	}
+	return result, nil
+}
+
+// splitBatch converts params into request bodies of at most maxBatchSize
+// operations, keeping the delete, patch, post order across requests
+func splitBatch(params BatchDNSRecordsParams) []batchRequest {
+	var reqs []batchRequest
+	var cur batchRequest
+	size := 0
+	add := func(fn func(*batchRequest)) {
+		if size == maxBatchSize {
+			reqs = append(reqs, cur)
+			cur, size = batchRequest{}, 0
+		}
+		fn(&cur)
+		size++
+	}
+
+	for _, id := range params.Deletes {
+		add(func(r *batchRequest) { r.Deletes = append(r.Deletes, batchID{ID: id}) })
+	}
+	for _, p := range params.Patches {
+		rec := batchRecord{
+			ID:       p.ID,
+			Type:     p.Params.Type,
+			Name:     p.Params.Name,
+			Content:  p.Params.Content,
+			Proxied:  p.Params.Proxied,
+			Priority: p.Params.Priority,
+			Comment:  p.Params.Comment,
+			Tags:     nonNilTags(p.Params.Tags),
+			Data:     p.Params.Data,
+		}
+		if p.Params.TTL != nil {
+			rec.TTL = *p.Params.TTL
+		}
+		add(func(r *batchRequest) { r.Patches = append(r.Patches, rec) })
+	}
+	for _, p := range params.Posts {
+		proxied := p.Proxied
+		rec := batchRecord{
+			Type:     p.Type,
+			Name:     p.Name,
+			Content:  p.Content,
+			TTL:      p.TTL,
+			Proxied:  &proxied,
+			Priority: p.Priority,
+			Tags:     nonNilTags(p.Tags),
+			Data:     p.Data,
+		}
+		if p.Comment != "" {
+			rec.Comment = &p.Comment
+		}
+		add(func(r *batchRequest) { r.Posts = append(r.Posts, rec) })
+	}
+
+	if size > 0 {
+		reqs = append(reqs, cur)
+	}
+	return reqs
+}
+
+// simulateBatch returns the records a batch would produce, without calling the API
+func simulateBatch(params BatchDNSRecordsParams) *BatchDNSRecordsResult {
+	result := &BatchDNSRecordsResult{}
+	for _, id := range params.Deletes {
+		result.Deletes = append(result.Deletes, DNSRecord{ID: id})
+	}
+	for _, p := range params.Patches {
+		r := DNSRecord{ID: p.ID, Type: p.Params.Type, Name: p.Params.Name, Content: p.Params.Content, Priority: p.Params.Priority, Tags: p.Params.Tags, Data: p.Params.Data}
+		if p.Params.TTL != nil {
+			r.TTL = *p.Params.TTL
+		}
+		r.Proxied = boolValue(p.Params.Proxied)
+		if p.Params.Comment != nil {
+			r.Comment = *p.Params.Comment
+		}
+		result.Patches = append(result.Patches, r)
+	}
+	for _, p := range params.Posts {
+		result.Posts = append(result.Posts, DNSRecord{
+			Type:     p.Type,
+			Name:     p.Name,
+			Content:  p.Content,
+			TTL:      p.TTL,
+			Proxied:  p.Proxied,
+			Priority: p.Priority,
+			Comment:  p.Comment,
+			Tags:     p.Tags,
+			Data:     p.Data,
+		})
+	}
+	return result
+}
+
+// nonNilTags returns tags, or an empty slice so that "no tags" is sent explicitly
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
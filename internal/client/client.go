@@ -2,39 +2,117 @@ package client
 
 import (
 	"context"
-	"errors"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/coollabsio/cloudflare-cli/internal/config"
+	"github.com/coollabsio/cloudflare-cli/internal/logging"
 )
 
-// Client wraps the Cloudflare API client with convenience methods
+// Client wraps the Cloudflare API client with convenience methods.
+// A Client is safe for concurrent use: the underlying cloudflare-go client and
+// its rate limiter are, and the transport guards its trace state with a mutex.
 type Client struct {
-	api *cloudflare.API
+	api       *cloudflare.API
+	transport *transport
+	rateLimit *rateLimitTransport
+	dryRun    bool
+	// headers are the extra headers from the config, for describeSkipped
+	headers http.Header
+	// curl receives the curl command of every request (--print-curl), or is nil
+	curl io.Writer
+	// httpClient sends requests cloudflare-go has no method for, through the
+	// same transports (retries, headers, tracing)
+	httpClient *http.Client
 }
 
 // New creates a new Cloudflare client from the given config
 func New(cfg *config.Config) (*Client, error) {
-	if !cfg.HasCredentials() {
-		return nil, errors.New("no credentials configured. Set CLOUDFLARE_API_TOKEN or CLOUDFLARE_API_KEY + CLOUDFLARE_API_EMAIL")
+	if err := cfg.CheckCredentials(); err != nil {
+		return nil, err
 	}
 
 	var api *cloudflare.API
 	var err error
 
+	// Retries are handled by our own transport so the retried statuses can be
+	// configured; cloudflare-go's built-in retries are turned off
+	retryOn := cfg.RetryOn
+	if retryOn == nil {
+		retryOn = DefaultRetryStatuses
+	}
+	if cfg.NoRetry {
+		retryOn = nil
+	}
+	maxRetries := cfg.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	}
+	rl := &rateLimitTransport{base: http.DefaultTransport}
+	t := &transport{base: newRetryTransport(rl, retryOn, maxRetries)}
+	var rt http.RoundTripper = t
+	// The curl transport sits inside the header transport, so printed
+	// commands carry the --header and config headers like the real request
+	var curl io.Writer
+	if cfg.PrintCurl {
+		curl = os.Stderr
+		rt = &curlTransport{base: rt, w: curl}
+	}
+	headers := make(http.Header)
+	if len(cfg.Headers) > 0 {
+		for name, value := range cfg.Headers {
+			headers.Set(name, value)
+		}
+		rt = &headerTransport{base: rt, headers: headers}
+	}
+	httpClient := &http.Client{Transport: rt}
+	opts := []cloudflare.Option{
+		cloudflare.HTTPClient(httpClient),
+		cloudflare.UsingLogger(logging.PrintfLogger{}),
+		cloudflare.UsingRetryPolicy(0, 0, 0),
+	}
+	if cfg.APIBaseURL != "" {
+		if err := CheckBaseURL(cfg.APIBaseURL); err != nil {
+			return nil, err
+		}
+		opts = append(opts, cloudflare.BaseURL(strings.TrimSuffix(cfg.APIBaseURL, "/")))
+	}
+
 	if cfg.APIToken != "" {
-		api, err = cloudflare.NewWithAPIToken(cfg.APIToken)
+		api, err = cloudflare.NewWithAPIToken(cfg.APIToken, opts...)
 	} else {
-		api, err = cloudflare.New(cfg.APIKey, cfg.APIEmail)
+		api, err = cloudflare.New(cfg.APIKey, cfg.APIEmail, opts...)
 	}
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	return &Client{api: api}, nil
+	return &Client{api: api, transport: t, rateLimit: rl, dryRun: cfg.DryRun, headers: headers, curl: curl, httpClient: httpClient}, nil
+}
+
+// DefaultBaseURL is the API endpoint used unless a base URL is configured
+const DefaultBaseURL = "https://api.cloudflare.com/client/v4"
+
+// CheckBaseURL checks that an API base URL is an absolute http(s) URL
+func CheckBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("invalid API base URL %q: expected an absolute URL such as %s", baseURL, DefaultBaseURL)
+	}
+	return nil
+}
+
+// DryRun reports whether mutating calls are skipped and simulated instead
+func (c *Client) DryRun() bool {
+	return c.dryRun
 }
 
 // VerifyToken verifies the API credentials are valid
@@ -45,7 +123,7 @@ func (c *Client) VerifyToken(ctx context.Context) error {
 		// Fallback to listing zones if token verification fails
 		_, err = c.api.ListZones(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to verify credentials: %w", err)
+			return fmt.Errorf("failed to verify credentials: %w", translateError(err))
 		}
 	}
 	return nil
@@ -53,9 +131,34 @@ func (c *Client) VerifyToken(ctx context.Context) error {
 
 // Zone represents a Cloudflare zone
 type Zone struct {
-	ID     string
-	Name   string
-	Status string
+	ID                  string
+	Name                string
+	Status              string
+	NameServers         []string
+	OriginalNameServers []string
+	VanityNameServers   []string
+	AccountID           string
+	AccountName         string
+	Plan                string
+	CreatedOn           time.Time
+	ModifiedOn          time.Time
+}
+
+// zoneFromAPI converts a cloudflare-go zone to a Zone
+func zoneFromAPI(z cloudflare.Zone) Zone {
+	return Zone{
+		ID:                  z.ID,
+		Name:                z.Name,
+		Status:              z.Status,
+		NameServers:         z.NameServers,
+		OriginalNameServers: z.OriginalNS,
+		VanityNameServers:   z.VanityNS,
+		AccountID:           z.Account.ID,
+		AccountName:         z.Account.Name,
+		Plan:                z.Plan.Name,
+		CreatedOn:           z.CreatedOn,
+		ModifiedOn:          z.ModifiedOn,
+	}
 }
 
 // ListZones returns all zones accessible by the current credentials
@@ -63,18 +166,14 @@ func (c *Client) ListZones(ctx context.Context) ([]Zone, error) {
 	zones, err := c.api.ListZones(ctx)
 	if err != nil {
 		if isPermissionError(err) {
-			return nil, fmt.Errorf("permission denied: your API token may not have 'Zone:Read' permission for all zones. %w", err)
+			return nil, fmt.Errorf("permission denied: your API token may not have 'Zone:Read' permission for all zones. %w", translateError(err))
 		}
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	var result []Zone
 	for _, z := range zones {
-		result = append(result, Zone{
-			ID:     z.ID,
-			Name:   z.Name,
-			Status: z.Status,
-		})
+		result = append(result, zoneFromAPI(z))
 	}
 	return result, nil
 }
@@ -85,11 +184,8 @@ func (c *Client) GetZone(ctx context.Context, nameOrID string) (*Zone, error) {
 	if looksLikeZoneID(nameOrID) {
 		zone, err := c.api.ZoneDetails(ctx, nameOrID)
 		if err == nil {
-			return &Zone{
-				ID:     zone.ID,
-				Name:   zone.Name,
-				Status: zone.Status,
-			}, nil
+			result := zoneFromAPI(zone)
+			return &result, nil
 		}
 		// If it failed, it might not be an ID after all, try by name
 	}
@@ -107,19 +203,354 @@ To fix this, either:
 
 Error: %w`, err)
 		}
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	if len(zones) == 0 {
 		return nil, fmt.Errorf("zone not found: %s", nameOrID)
 	}
 
-	z := zones[0]
-	return &Zone{
-		ID:     z.ID,
-		Name:   z.Name,
-		Status: z.Status,
-	}, nil
+	result := zoneFromAPI(zones[0])
+	return &result, nil
+}
+
+// CreateZone adds a zone to an account. The returned zone carries the
+// nameservers Cloudflare assigned to it.
+func (c *Client) CreateZone(ctx context.Context, name, accountID string) (*Zone, error) {
+	if c.dryRun {
+		logging.Logger.Info("zone create skipped (dry-run)", "name", name, "account_id", accountID)
+		c.describeSkipped(http.MethodPost, "/zones", map[string]interface{}{
+			"name":    name,
+			"account": map[string]string{"id": accountID},
+			"type":    "full",
+		})
+		return &Zone{Name: name, Status: "pending", AccountID: accountID}, nil
+	}
+
+	zone, err := c.api.CreateZone(ctx, name, false, cloudflare.Account{ID: accountID}, "full")
+	if err != nil {
+		logging.Logger.Error("zone create failed", "name", name, "account_id", accountID, "error", err)
+		return nil, fmt.Errorf("failed to create zone %s: %w", name, translateError(err))
+	}
+	logging.Logger.Info("zone created", "zone_id", zone.ID, "name", name)
+	result := zoneFromAPI(zone)
+	return &result, nil
+}
+
+// ActivateZone asks Cloudflare to re-check the nameserver delegation of a pending zone
+func (c *Client) ActivateZone(ctx context.Context, zoneID string) error {
+	if c.dryRun {
+		logging.Logger.Info("zone activation check skipped (dry-run)", "zone_id", zoneID)
+		c.describeSkipped(http.MethodPut, "/zones/"+zoneID+"/activation_check", nil)
+		return nil
+	}
+
+	if _, err := c.api.ZoneActivationCheck(ctx, zoneID); err != nil {
+		return fmt.Errorf("failed to trigger activation check: %w", translateError(err))
+	}
+	return nil
+}
+
+// Account represents a Cloudflare account
+type Account struct {
+	ID   string
+	Name string
+}
+
+// ListAccounts returns all accounts the credentials have access to
+func (c *Client) ListAccounts(ctx context.Context) ([]Account, error) {
+	var result []Account
+	for page := 1; ; page++ {
+		accounts, info, err := c.api.Accounts(ctx, cloudflare.AccountsListParams{
+			PaginationOptions: cloudflare.PaginationOptions{Page: page, PerPage: 50},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list accounts: %w", translateError(err))
+		}
+		for _, a := range accounts {
+			result = append(result, Account{ID: a.ID, Name: a.Name})
+		}
+		if page >= info.TotalPages {
+			return result, nil
+		}
+	}
+}
+
+// ResolveAccountID resolves an account name or ID to an account ID. Names are
+// matched case-insensitively and must identify exactly one account.
+func (c *Client) ResolveAccountID(ctx context.Context, nameOrID string) (string, error) {
+	accounts, err := c.ListAccounts(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var matches []Account
+	for _, a := range accounts {
+		if a.ID == nameOrID {
+			return a.ID, nil
+		}
+		if strings.EqualFold(a.Name, nameOrID) {
+			matches = append(matches, a)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("account not found: %s", nameOrID)
+	case 1:
+		return matches[0].ID, nil
+	}
+	var ids []string
+	for _, a := range matches {
+		ids = append(ids, a.ID)
+	}
+	return "", fmt.Errorf("account name %q is ambiguous (matches %s); use the account ID", nameOrID, strings.Join(ids, ", "))
+}
+
+// ZoneUsage is the number of zones in an account, with the zone limit of the
+// account's subscriptions if Cloudflare reports one
+type ZoneUsage struct {
+	AccountID   string
+	AccountName string
+	Zones       int
+	Active      int
+	Pending     int
+	// Limit is nil when no subscription reports a zone limit
+	Limit *int
+}
+
+// subscriptionResult is a subscription as returned by the account subscriptions endpoint
+type subscriptionResult struct {
+	ComponentValues []struct {
+		Name  string `json:"name"`
+		Value int    `json:"value"`
+	} `json:"component_values"`
+}
+
+// ZoneUsage counts the zones in each accessible account and looks up the zone
+// limit of each account's subscriptions. A limit that cannot be read (e.g. for
+// lack of billing permissions) is left unset rather than failing.
+func (c *Client) ZoneUsage(ctx context.Context) ([]ZoneUsage, error) {
+	zones, err := c.ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ZoneUsage
+	index := make(map[string]int)
+	for _, z := range zones {
+		i, ok := index[z.AccountID]
+		if !ok {
+			i = len(result)
+			index[z.AccountID] = i
+			result = append(result, ZoneUsage{AccountID: z.AccountID, AccountName: z.AccountName})
+		}
+		result[i].Zones++
+		switch z.Status {
+		case "active":
+			result[i].Active++
+		case "pending":
+			result[i].Pending++
+		}
+	}
+
+	for i := range result {
+		result[i].Limit = c.zoneLimit(ctx, result[i].AccountID)
+	}
+	return result, nil
+}
+
+// zoneLimit returns the "zones" component of an account's subscriptions, or nil
+func (c *Client) zoneLimit(ctx context.Context, accountID string) *int {
+	raw, err := c.api.Raw(ctx, http.MethodGet, fmt.Sprintf("/accounts/%s/subscriptions", accountID), nil, nil)
+	if err != nil {
+		logging.Logger.Debug("account subscriptions unavailable", "account_id", accountID, "error", err)
+		return nil
+	}
+
+	var subscriptions []subscriptionResult
+	if err := json.Unmarshal(raw.Result, &subscriptions); err != nil {
+		return nil
+	}
+	var limit *int
+	for _, sub := range subscriptions {
+		for _, cv := range sub.ComponentValues {
+			if cv.Name == "zones" {
+				total := cv.Value
+				if limit != nil {
+					total += *limit
+				}
+				limit = &total
+			}
+		}
+	}
+	return limit
+}
+
+// Membership is the current user's membership in an account
+type Membership struct {
+	AccountID   string
+	AccountName string
+	Status      string
+	Roles       []string
+}
+
+// membershipResult is a membership as returned by the memberships endpoint
+type membershipResult struct {
+	Status  string   `json:"status"`
+	Roles   []string `json:"roles"`
+	Account struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"account"`
+}
+
+// ListMemberships returns the current user's account memberships. This needs
+// user-level access (an API key, or a token with "Memberships Read").
+func (c *Client) ListMemberships(ctx context.Context) ([]Membership, error) {
+	var result []Membership
+	for page := 1; ; page++ {
+		raw, err := c.api.Raw(ctx, http.MethodGet, fmt.Sprintf("/memberships?page=%d&per_page=50", page), nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list account memberships: %w", translateError(err))
+		}
+
+		var memberships []membershipResult
+		if err := json.Unmarshal(raw.Result, &memberships); err != nil {
+			return nil, fmt.Errorf("failed to decode account memberships: %w", err)
+		}
+		for _, m := range memberships {
+			result = append(result, Membership{
+				AccountID:   m.Account.ID,
+				AccountName: m.Account.Name,
+				Status:      m.Status,
+				Roles:       m.Roles,
+			})
+		}
+
+		if raw.ResultInfo == nil || page >= raw.ResultInfo.TotalPages {
+			return result, nil
+		}
+	}
+}
+
+// CustomNameserverSettings is a zone's account custom nameserver configuration
+type CustomNameserverSettings struct {
+	Enabled bool
+	NSSet   int
+}
+
+// GetCustomNameserverSettings returns whether account custom nameservers are
+// enabled for a zone. Plans without custom nameservers return an error.
+func (c *Client) GetCustomNameserverSettings(ctx context.Context, zoneID string) (*CustomNameserverSettings, error) {
+	meta, err := c.api.GetCustomNameserverZoneMetadata(ctx, cloudflare.ZoneIdentifier(zoneID), cloudflare.GetCustomNameserverZoneMetadataParams{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom nameserver settings: %w", translateError(err))
+	}
+	return &CustomNameserverSettings{Enabled: meta.Enabled, NSSet: meta.NSSet}, nil
+}
+
+// ZonePlan is a subscription plan available to a zone
+type ZonePlan struct {
+	ID           string
+	Name         string
+	Price        int
+	Currency     string
+	Frequency    string
+	IsSubscribed bool
+	CanSubscribe bool
+}
+
+// ListZonePlans returns the plans available to a zone, including the one it
+// is currently subscribed to
+func (c *Client) ListZonePlans(ctx context.Context, zoneID string) ([]ZonePlan, error) {
+	plans, err := c.api.AvailableZonePlans(ctx, zoneID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zone plans: %w", translateError(err))
+	}
+
+	var result []ZonePlan
+	for _, p := range plans {
+		result = append(result, ZonePlan{
+			ID:           p.ID,
+			Name:         p.Name,
+			Price:        p.Price,
+			Currency:     p.Currency,
+			Frequency:    p.Frequency,
+			IsSubscribed: p.IsSubscribed,
+			CanSubscribe: p.CanSubscribe,
+		})
+	}
+	return result, nil
+}
+
+// SetZonePlan changes the plan a zone is subscribed to. Zones on a paid plan
+// already have a subscription, which is updated; otherwise one is created.
+func (c *Client) SetZonePlan(ctx context.Context, zoneID, planID string) error {
+	zone, err := c.api.ZoneDetails(ctx, zoneID)
+	if err != nil {
+		return fmt.Errorf("failed to get zone: %w", translateError(err))
+	}
+
+	if c.dryRun {
+		logging.Logger.Info("zone plan change skipped (dry-run)", "zone_id", zoneID, "plan_id", planID)
+		method := http.MethodPost
+		if zone.Plan.Price > 0 {
+			method = http.MethodPut
+		}
+		c.describeSkipped(method, fmt.Sprintf("/zones/%s/subscription", zoneID), map[string]interface{}{"rate_plan": map[string]string{"id": planID}})
+		return nil
+	}
+
+	if zone.Plan.Price > 0 {
+		err = c.api.ZoneUpdatePlan(ctx, zoneID, planID)
+	} else {
+		err = c.api.ZoneSetPlan(ctx, zoneID, planID)
+	}
+	if err != nil {
+		logging.Logger.Error("zone plan change failed", "zone_id", zoneID, "plan_id", planID, "error", err)
+		return fmt.Errorf("failed to change zone plan: %w", translateError(err))
+	}
+	logging.Logger.Info("zone plan changed", "zone_id", zoneID, "plan_id", planID)
+	return nil
+}
+
+// AccessRule is an IP access rule that applies to a zone
+type AccessRule struct {
+	ID string
+	// Mode is block, challenge, js_challenge, managed_challenge, or whitelist
+	Mode string
+	// Target is what the rule matches on: ip, ip_range, ip6, asn, or country
+	Target string
+	Value  string
+	Notes  string
+	// Scope is where the rule is defined: zone, account, or user
+	Scope string
+}
+
+// ListAccessRules returns the IP access rules that apply to a zone, optionally
+// only those with the given mode
+func (c *Client) ListAccessRules(ctx context.Context, zoneID, mode string) ([]AccessRule, error) {
+	var result []AccessRule
+	for page := 1; ; page++ {
+		resp, err := c.api.ListZoneAccessRules(ctx, zoneID, cloudflare.AccessRule{Mode: mode}, page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list access rules: %w", translateError(err))
+		}
+		for _, r := range resp.Result {
+			result = append(result, AccessRule{
+				ID:     r.ID,
+				Mode:   r.Mode,
+				Target: r.Configuration.Target,
+				Value:  r.Configuration.Value,
+				Notes:  r.Notes,
+				Scope:  r.Scope.Type,
+			})
+		}
+		if page >= resp.ResultInfo.TotalPages {
+			return result, nil
+		}
+	}
 }
 
 // ResolveZoneID resolves a zone name or ID to a zone ID
@@ -133,14 +564,43 @@ func (c *Client) ResolveZoneID(ctx context.Context, nameOrID string) (string, er
 
 // DNSRecord represents a DNS record
 type DNSRecord struct {
-	ID       string
-	Type     string
-	Name     string
-	Content  string
-	TTL      int
-	Proxied  bool
-	Priority *uint16
-	Comment  string
+	ID        string
+	Type      string
+	Name      string
+	Content   string
+	TTL       int
+	Proxied   bool
+	Proxiable bool
+	Priority  *uint16
+	Comment   string
+	Tags      []string
+	// Data holds the structured fields of types such as LOC, SRV, or TLSA
+	Data map[string]interface{} `json:",omitempty"`
+}
+
+// recordFromAPI converts a cloudflare-go DNS record to a DNSRecord
+func recordFromAPI(r cloudflare.DNSRecord) DNSRecord {
+	return DNSRecord{
+		ID:        r.ID,
+		Type:      r.Type,
+		Name:      r.Name,
+		Content:   r.Content,
+		TTL:       r.TTL,
+		Proxied:   boolValue(r.Proxied),
+		Proxiable: r.Proxiable,
+		Priority:  r.Priority,
+		Comment:   r.Comment,
+		Tags:      r.Tags,
+		Data:      recordData(r.Data),
+	}
+}
+
+// recordData returns the structured data of a record, if it has any
+func recordData(data interface{}) map[string]interface{} {
+	if m, ok := data.(map[string]interface{}); ok && len(m) > 0 {
+		return m
+	}
+	return nil
 }
 
 // ListDNSRecords returns DNS records for a zone
@@ -159,22 +619,12 @@ func (c *Client) ListDNSRecords(ctx context.Context, zoneID string, recordType,
 		Name: filter.Name,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list DNS records: %w", err)
+		return nil, fmt.Errorf("failed to list DNS records: %w", translateError(err))
 	}
 
 	var result []DNSRecord
 	for _, r := range records {
-		rec := DNSRecord{
-			ID:       r.ID,
-			Type:     r.Type,
-			Name:     r.Name,
-			Content:  r.Content,
-			TTL:      r.TTL,
-			Proxied:  boolValue(r.Proxied),
-			Priority: r.Priority,
-			Comment:  r.Comment,
-		}
-		result = append(result, rec)
+		result = append(result, recordFromAPI(r))
 	}
 	return result, nil
 }
@@ -184,19 +634,11 @@ func (c *Client) GetDNSRecord(ctx context.Context, zoneID, recordID string) (*DN
 	rc := cloudflare.ZoneIdentifier(zoneID)
 	r, err := c.api.GetDNSRecord(ctx, rc, recordID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get DNS record: %w", err)
+		return nil, fmt.Errorf("failed to get DNS record: %w", translateError(err))
 	}
 
-	return &DNSRecord{
-		ID:       r.ID,
-		Type:     r.Type,
-		Name:     r.Name,
-		Content:  r.Content,
-		TTL:      r.TTL,
-		Proxied:  boolValue(r.Proxied),
-		Priority: r.Priority,
-		Comment:  r.Comment,
-	}, nil
+	record := recordFromAPI(r)
+	return &record, nil
 }
 
 // CreateDNSRecordParams contains parameters for creating a DNS record
@@ -208,6 +650,9 @@ type CreateDNSRecordParams struct {
 	Proxied  bool
 	Priority *uint16
 	Comment  string
+	Tags     []string
+	// Data is sent instead of Content for types that take structured data
+	Data map[string]interface{}
 }
 
 // CreateDNSRecord creates a new DNS record
@@ -222,23 +667,78 @@ func (c *Client) CreateDNSRecord(ctx context.Context, zoneID string, params Crea
 		Proxied:  &params.Proxied,
 		Priority: params.Priority,
 		Comment:  params.Comment,
+		Tags:     params.Tags,
+	}
+	if params.Data != nil {
+		createParams.Data = params.Data
 	}
 
+	if c.dryRun {
+		logging.Logger.Info("dns record create skipped (dry-run)", "zone_id", zoneID, "type", params.Type, "name", params.Name)
+		c.describeSkipped(http.MethodPost, fmt.Sprintf("/zones/%s/dns_records", zoneID), createParams)
+		return &DNSRecord{
+			Type:     params.Type,
+			Name:     params.Name,
+			Content:  params.Content,
+			TTL:      params.TTL,
+			Proxied:  params.Proxied,
+			Priority: params.Priority,
+			Comment:  params.Comment,
+			Tags:     params.Tags,
+			Data:     params.Data,
+		}, nil
+	}
+
+	// Cloudflare has no idempotency keys, so a create that failed with a
+	// server error (e.g. a 504 after the record was stored) is only retried
+	// once the record is known not to exist
+	var existing *DNSRecord
+	uncertain := false
+	ctx = withBeforeRetry(ctx, func(ctx context.Context) bool {
+		found, err := c.findCreatedRecord(ctx, zoneID, params)
+		if err != nil {
+			uncertain = true
+			return false
+		}
+		existing = found
+		return found == nil
+	})
+
 	r, err := c.api.CreateDNSRecord(ctx, rc, createParams)
+	if err != nil && existing != nil {
+		logging.Logger.Info("dns record found after failed create, not retrying", "zone_id", zoneID, "record_id", existing.ID, "type", existing.Type, "name", existing.Name)
+		return existing, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to create DNS record: %w", err)
+		logging.Logger.Error("dns record create failed", "zone_id", zoneID, "type", params.Type, "name", params.Name, "error", err)
+		if uncertain {
+			return nil, fmt.Errorf("failed to create DNS record (it may have been created anyway; check with 'cf dns list' before retrying): %w", translateError(err))
+		}
+		return nil, fmt.Errorf("failed to create DNS record: %w", translateError(err))
 	}
+	logging.Logger.Info("dns record created", "zone_id", zoneID, "record_id", r.ID, "type", r.Type, "name", r.Name)
 
-	return &DNSRecord{
-		ID:       r.ID,
-		Type:     r.Type,
-		Name:     r.Name,
-		Content:  r.Content,
-		TTL:      r.TTL,
-		Proxied:  boolValue(r.Proxied),
-		Priority: r.Priority,
-		Comment:  r.Comment,
-	}, nil
+	record := recordFromAPI(r)
+	return &record, nil
+}
+
+// findCreatedRecord looks for a record matching a create that failed, so a
+// create that reached Cloudflare isn't sent twice. Records given only as
+// structured data can't be matched reliably and return an error.
+func (c *Client) findCreatedRecord(ctx context.Context, zoneID string, params CreateDNSRecordParams) (*DNSRecord, error) {
+	if params.Content == "" {
+		return nil, fmt.Errorf("cannot match a %s record without content", params.Type)
+	}
+	records, err := c.ListDNSRecords(ctx, zoneID, params.Type, params.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range records {
+		if r.Content == params.Content || (params.Type != "TXT" && strings.EqualFold(strings.TrimSuffix(r.Content, "."), strings.TrimSuffix(params.Content, "."))) {
+			return &r, nil
+		}
+	}
+	return nil, nil
 }
 
 // UpdateDNSRecordParams contains parameters for updating a DNS record
@@ -250,6 +750,11 @@ type UpdateDNSRecordParams struct {
 	Proxied  *bool
 	Priority *uint16
 	Comment  *string
+	// Tags replaces the record's tags. cloudflare-go always sends tags on
+	// update, so pass the existing tags to keep them.
+	Tags []string
+	// Data replaces the record's structured data; nil leaves it unchanged
+	Data map[string]interface{}
 }
 
 // UpdateDNSRecord updates an existing DNS record
@@ -264,36 +769,83 @@ func (c *Client) UpdateDNSRecord(ctx context.Context, zoneID, recordID string, p
 		Proxied:  params.Proxied,
 		Priority: params.Priority,
 		Comment:  params.Comment,
+		Tags:     params.Tags,
+	}
+	if params.Data != nil {
+		updateParams.Data = params.Data
 	}
 
 	if params.TTL != nil {
 		updateParams.TTL = *params.TTL
 	}
 
+	if c.dryRun {
+		logging.Logger.Info("dns record update skipped (dry-run)", "zone_id", zoneID, "record_id", recordID)
+		c.describeSkipped(http.MethodPatch, fmt.Sprintf("/zones/%s/dns_records/%s", zoneID, recordID), updateParams)
+		return c.simulateUpdate(ctx, zoneID, recordID, params)
+	}
+
 	r, err := c.api.UpdateDNSRecord(ctx, rc, updateParams)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update DNS record: %w", err)
+		logging.Logger.Error("dns record update failed", "zone_id", zoneID, "record_id", recordID, "error", err)
+		return nil, fmt.Errorf("failed to update DNS record: %w", translateError(err))
 	}
+	logging.Logger.Info("dns record updated", "zone_id", zoneID, "record_id", r.ID, "type", r.Type, "name", r.Name)
 
-	return &DNSRecord{
-		ID:       r.ID,
-		Type:     r.Type,
-		Name:     r.Name,
-		Content:  r.Content,
-		TTL:      r.TTL,
-		Proxied:  boolValue(r.Proxied),
-		Priority: r.Priority,
-		Comment:  r.Comment,
-	}, nil
+	record := recordFromAPI(r)
+	return &record, nil
+}
+
+// simulateUpdate returns the record as it would look after applying params, without changing it
+func (c *Client) simulateUpdate(ctx context.Context, zoneID, recordID string, params UpdateDNSRecordParams) (*DNSRecord, error) {
+	r, err := c.GetDNSRecord(ctx, zoneID, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Type != "" {
+		r.Type = params.Type
+	}
+	if params.Name != "" {
+		r.Name = params.Name
+	}
+	if params.Content != "" {
+		r.Content = params.Content
+	}
+	if params.TTL != nil {
+		r.TTL = *params.TTL
+	}
+	if params.Proxied != nil {
+		r.Proxied = *params.Proxied
+	}
+	if params.Priority != nil {
+		r.Priority = params.Priority
+	}
+	if params.Comment != nil {
+		r.Comment = *params.Comment
+	}
+	if params.Data != nil {
+		r.Data = params.Data
+	}
+	r.Tags = params.Tags
+	return r, nil
 }
 
 // DeleteDNSRecord deletes a DNS record
 func (c *Client) DeleteDNSRecord(ctx context.Context, zoneID, recordID string) error {
+	if c.dryRun {
+		logging.Logger.Info("dns record delete skipped (dry-run)", "zone_id", zoneID, "record_id", recordID)
+		c.describeSkipped(http.MethodDelete, fmt.Sprintf("/zones/%s/dns_records/%s", zoneID, recordID), nil)
+		return nil
+	}
+
 	rc := cloudflare.ZoneIdentifier(zoneID)
 	err := c.api.DeleteDNSRecord(ctx, rc, recordID)
 	if err != nil {
-		return fmt.Errorf("failed to delete DNS record: %w", err)
+		logging.Logger.Error("dns record delete failed", "zone_id", zoneID, "record_id", recordID, "error", err)
+		return fmt.Errorf("failed to delete DNS record: %w", translateError(err))
 	}
+	logging.Logger.Info("dns record deleted", "zone_id", zoneID, "record_id", recordID)
 	return nil
 }
 
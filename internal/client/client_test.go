@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/coollabsio/cloudflare-cli/internal/config"
+)
+
+const testZoneID = "023e105f4ecef8ad9ca31a8372d0c353"
+
+// flakyDNSAPI serves a zone's DNS records and answers the first create with
+// a gateway timeout, storing the record first if stored is set (the create
+// reached Cloudflare but the response was lost)
+type flakyDNSAPI struct {
+	stored bool
+
+	mu      sync.Mutex
+	records []cloudflare.DNSRecord
+	creates int
+}
+
+func (f *flakyDNSAPI) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if req.URL.Path != "/zones/"+testZoneID+"/dns_records" {
+		http.NotFound(w, req)
+		return
+	}
+
+	var result interface{}
+	switch req.Method {
+	case http.MethodGet:
+		matched := []cloudflare.DNSRecord{}
+		q := req.URL.Query()
+		for _, r := range f.records {
+			if (q.Get("type") == "" || q.Get("type") == r.Type) && (q.Get("name") == "" || q.Get("name") == r.Name) {
+				matched = append(matched, r)
+			}
+		}
+		result = matched
+	case http.MethodPost:
+		f.creates++
+		var r cloudflare.DNSRecord
+		json.NewDecoder(req.Body).Decode(&r)
+		r.ID = fmt.Sprintf("rec%d", f.creates)
+		if f.creates > 1 || f.stored {
+			f.records = append(f.records, r)
+		}
+		if f.creates == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		result = r
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true, "errors": []interface{}{}, "messages": []interface{}{}, "result": result,
+		"result_info": map[string]int{"page": 1, "per_page": 100, "count": 1, "total_count": 1, "total_pages": 1},
+	})
+}
+
+func TestCreateDNSRecordRetryAfterTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		stored      bool
+		wantCreates int
+	}{
+		{"created before the timeout", true, 1},
+		{"not created before the timeout", false, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &flakyDNSAPI{stored: tt.stored}
+			server := httptest.NewServer(api)
+			defer server.Close()
+			c, err := New(&config.Config{APIToken: "test-token", APIBaseURL: server.URL})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := c.CreateDNSRecord(context.Background(), testZoneID, CreateDNSRecordParams{
+				Type: "A", Name: "www.example.com", Content: "192.0.2.1", TTL: 1,
+			})
+			if err != nil {
+				t.Fatalf("CreateDNSRecord() error = %v", err)
+			}
+			if r.Name != "www.example.com" || r.Content != "192.0.2.1" {
+				t.Errorf("CreateDNSRecord() = %+v, want the www.example.com record", r)
+			}
+			if api.creates != tt.wantCreates {
+				t.Errorf("%d create requests, want %d", api.creates, tt.wantCreates)
+			}
+			if len(api.records) != 1 {
+				t.Errorf("%d records stored, want 1 (no duplicate)", len(api.records))
+			}
+		})
+	}
+}
+
+func TestActivateZoneDryRun(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		http.NotFound(w, req)
+	}))
+	defer server.Close()
+	c, err := New(&config.Config{APIToken: "test-token", APIBaseURL: server.URL, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.ActivateZone(context.Background(), testZoneID); err != nil {
+		t.Errorf("ActivateZone() error = %v", err)
+	}
+	if requests > 0 {
+		t.Errorf("%d request(s) sent in dry-run mode, want none", requests)
+	}
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/output"
+)
+
+// curlPlaceholders replace credential headers in printed curl commands with
+// the environment variables that hold them, so the command can be re-run
+var curlPlaceholders = map[string]string{
+	"Authorization": "Bearer $CLOUDFLARE_API_TOKEN",
+	"X-Auth-Key":    "$CLOUDFLARE_API_KEY",
+	"X-Auth-Email":  "$CLOUDFLARE_API_EMAIL",
+}
+
+// curlTransport prints every request as an equivalent curl command before
+// sending it
+type curlTransport struct {
+	base http.RoundTripper
+	w    io.Writer
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *curlTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	fmt.Fprintln(t.w, curlCommand(req.Method, req.URL.String(), req.Header, body))
+	return t.base.RoundTrip(req)
+}
+
+// curlCommand renders a request as a curl command line. Credential headers are
+// replaced by environment variable references or redacted.
+func curlCommand(method, url string, header http.Header, body []byte) string {
+	parts := []string{"curl", "-X", method, output.ShellQuote(url)}
+
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if name == "User-Agent" {
+			continue
+		}
+		value := header.Get(name)
+		if placeholder, ok := curlPlaceholders[name]; ok {
+			// Double quotes so the shell expands the variable
+			parts = append(parts, "-H", fmt.Sprintf("\"%s: %s\"", name, placeholder))
+			continue
+		}
+		if slices.Contains(sensitiveHeaders, name) {
+			value = "[redacted]"
+		}
+		parts = append(parts, "-H", output.ShellQuote(name+": "+value))
+	}
+
+	if len(body) > 0 {
+		parts = append(parts, "--data", output.ShellQuote(string(body)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// describeSkipped prints the curl command for a request that dry-run skipped,
+// when --print-curl is on. The headers are the ones cloudflare-go would send.
+func (c *Client) describeSkipped(method, path string, body interface{}) {
+	if c.curl == nil {
+		return
+	}
+
+	header := make(http.Header)
+	for name, values := range c.headers {
+		header[name] = values
+	}
+	if c.api.APIToken != "" {
+		header.Set("Authorization", "Bearer "+c.api.APIToken)
+	} else {
+		header.Set("X-Auth-Key", c.api.APIKey)
+		header.Set("X-Auth-Email", c.api.APIEmail)
+	}
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "application/json")
+	}
+
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			fmt.Fprintf(c.curl, "# failed to encode request body: %v\n", err)
+		}
+	}
+	fmt.Fprintf(c.curl, "# skipped (dry-run)\n%s\n", curlCommand(method, c.api.BaseURL+path, header, data))
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// APIError is a Cloudflare API error translated into an actionable message.
+// The original cloudflare-go error is kept and can be reached with errors.Unwrap.
+type APIError struct {
+	Code    int
+	Message string
+	Hint    string
+	Err     error
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s (Cloudflare error %d)", e.Message, e.Code)
+	if e.Hint != "" {
+		msg += "\n  Hint: " + e.Hint
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// errorCodeInfo is the message and suggested fix for a known error code
+type errorCodeInfo struct {
+	Message string
+	Hint    string
+}
+
+// knownErrorCodes maps common Cloudflare API error codes to actionable messages
+var knownErrorCodes = map[int]errorCodeInfo{
+	1003:  {"invalid or missing zone", "check the zone name or ID with: cf zones list"},
+	1049:  {"zone name is not a registered domain", "check the domain spelling; subdomains cannot be added as zones"},
+	1061:  {"zone already exists", "the zone is already on Cloudflare; list it with: cf zones list"},
+	6003:  {"invalid request headers", "check that your API token or key is set correctly"},
+	7003:  {"invalid object identifier", "check the zone and record IDs; find them with: cf zones list / cf dns list <zone>"},
+	9041:  {"this record type cannot be proxied", "only A, AAAA, and CNAME records can be proxied; drop --proxied"},
+	9103:  {"unknown API key or email", "check CLOUDFLARE_API_KEY and CLOUDFLARE_API_EMAIL, or use an API token"},
+	9106:  {"missing authentication", "set CLOUDFLARE_API_TOKEN or run: cf auth save <token>"},
+	9109:  {"invalid or expired API token", "create a new token in the Cloudflare dashboard and run: cf auth save <token>"},
+	10000: {"authentication error or missing permissions", "make sure the token has the required permissions (e.g. Zone:Read, DNS:Edit) for this zone"},
+	81044: {"record does not exist or has already been deleted", "list the current records with: cf dns list <zone>"},
+	81053: {"a record with the same name already exists", "update the existing record with: cf dns update, or pick a different name"},
+	81054: {"a CNAME record already exists with that name", "a CNAME cannot coexist with other records; update or delete the CNAME first"},
+	81057: {"an identical record already exists", "nothing to do, or use cf dns update to change it"},
+	81058: {"an identical record already exists", "nothing to do, or use cf dns update to change it"},
+}
+
+// translateError replaces a Cloudflare API error carrying a known error code
+// with an APIError. Other errors are returned unchanged.
+func translateError(err error) error {
+	var cfErr *cloudflare.Error
+	if !errors.As(err, &cfErr) {
+		return err
+	}
+	for _, code := range cfErr.ErrorCodes {
+		if info, ok := knownErrorCodes[code]; ok {
+			return &APIError{Code: code, Message: info.Message, Hint: info.Hint, Err: err}
+		}
+	}
+	return err
+}
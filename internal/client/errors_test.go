@@ -0,0 +1,85 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		code        int
+		wantMessage string
+		wantHint    string
+	}{
+		{1003, "invalid or missing zone", "cf zones list"},
+		{1049, "zone name is not a registered domain", "subdomains cannot be added"},
+		{1061, "zone already exists", "cf zones list"},
+		{6003, "invalid request headers", "API token or key"},
+		{7003, "invalid object identifier", "cf dns list <zone>"},
+		{9041, "this record type cannot be proxied", "drop --proxied"},
+		{9103, "unknown API key or email", "CLOUDFLARE_API_KEY"},
+		{9106, "missing authentication", "CLOUDFLARE_API_TOKEN"},
+		{9109, "invalid or expired API token", "cf auth save <token>"},
+		{10000, "authentication error or missing permissions", "DNS:Edit"},
+		{81044, "record does not exist or has already been deleted", "cf dns list <zone>"},
+		{81053, "a record with the same name already exists", "cf dns update"},
+		{81054, "a CNAME record already exists with that name", "delete the CNAME first"},
+		{81057, "an identical record already exists", "nothing to do"},
+		{81058, "an identical record already exists", "nothing to do"},
+	}
+	if len(tests) != len(knownErrorCodes) {
+		t.Errorf("%d codes tested, %d handled; add the new codes here", len(tests), len(knownErrorCodes))
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.code), func(t *testing.T) {
+			cfErr := &cloudflare.Error{StatusCode: 400, ErrorCodes: []int{tt.code}, ErrorMessages: []string{"raw message"}}
+			err := translateError(fmt.Errorf("creating record: %w", cfErr))
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("translateError() = %v, want an *APIError", err)
+			}
+			if apiErr.Code != tt.code || apiErr.Message != tt.wantMessage || !strings.Contains(apiErr.Hint, tt.wantHint) {
+				t.Errorf("got %d %q (hint %q), want %d %q (hint containing %q)", apiErr.Code, apiErr.Message, apiErr.Hint, tt.code, tt.wantMessage, tt.wantHint)
+			}
+			if want := fmt.Sprintf("(Cloudflare error %d)", tt.code); !strings.Contains(err.Error(), want) {
+				t.Errorf("Error() = %q, want it to contain %q", err.Error(), want)
+			}
+			var original *cloudflare.Error
+			if !errors.As(err, &original) || original != cfErr {
+				t.Error("the original cloudflare-go error is not reachable with errors.As")
+			}
+		})
+	}
+}
+
+func TestTranslateErrorUnknown(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"unknown code", &cloudflare.Error{StatusCode: 400, ErrorCodes: []int{99999}}},
+		{"no codes", &cloudflare.Error{StatusCode: 500}},
+		{"not an API error", errors.New("connection refused")},
+		{"nil", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := translateError(tt.err); got != tt.err {
+				t.Errorf("translateError() = %v, want the error unchanged", got)
+			}
+		})
+	}
+}
+
+func TestTranslateErrorFirstKnownCode(t *testing.T) {
+	err := translateError(&cloudflare.Error{StatusCode: 400, ErrorCodes: []int{99999, 81057, 1003}})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 81057 {
+		t.Errorf("translateError() = %v, want the first known code 81057", err)
+	}
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// headerTransport adds fixed headers to every request, e.g. a Cloudflare
+// Access service token for an Access-protected API gateway
+type headerTransport struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range t.headers {
+		req.Header[name] = values
+	}
+	return t.base.RoundTrip(req)
+}
+
+// ParseHeader parses a "Name: Value" header as given to --header
+func ParseHeader(s string) (name, value string, err error) {
+	name, value, ok := strings.Cut(s, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || strings.ContainsAny(name, " \t") {
+		return "", "", fmt.Errorf("invalid header %q: expected 'Name: Value'", s)
+	}
+	return name, strings.TrimSpace(value), nil
+}
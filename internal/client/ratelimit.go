@@ -0,0 +1,145 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coollabsio/cloudflare-cli/internal/logging"
+)
+
+// RateLimit is the API rate-limit budget reported by the last response
+type RateLimit struct {
+	// Policy is the name of the reported policy, if any
+	Policy string
+	// Limit and Remaining are the quota and what is left of it (nil if not reported)
+	Limit     *int
+	Remaining *int
+	// Reset is how long after ObservedAt the quota is restored (0 if not reported)
+	Reset time.Duration
+	// Window is the length of the quota window (0 if not reported)
+	Window     time.Duration
+	ObservedAt time.Time
+}
+
+// parseRateLimit reads the rate-limit headers of a response. Cloudflare sends
+// the structured Ratelimit / Ratelimit-Policy headers ("default";r=1199;t=300);
+// the older RateLimit-* and X-RateLimit-* forms are understood as well.
+func parseRateLimit(h http.Header, now time.Time) (RateLimit, bool) {
+	rl := RateLimit{ObservedAt: now}
+	found := false
+
+	if policy, params := firstStructuredItem(h.Get("Ratelimit")); policy != "" || len(params) > 0 {
+		rl.Policy = policy
+		if n, ok := params["r"]; ok {
+			rl.Remaining = &n
+		}
+		if n, ok := params["t"]; ok {
+			rl.Reset = time.Duration(n) * time.Second
+		}
+		found = true
+	}
+	if policy, params := firstStructuredItem(h.Get("Ratelimit-Policy")); policy != "" || len(params) > 0 {
+		if rl.Policy == "" {
+			rl.Policy = policy
+		}
+		if n, ok := params["q"]; ok {
+			rl.Limit = &n
+		}
+		if n, ok := params["w"]; ok {
+			rl.Window = time.Duration(n) * time.Second
+		}
+		found = true
+	}
+
+	for _, prefix := range []string{"X-Ratelimit-", "Ratelimit-"} {
+		if n, err := strconv.Atoi(h.Get(prefix + "Limit")); err == nil && rl.Limit == nil {
+			rl.Limit = &n
+			found = true
+		}
+		if n, err := strconv.Atoi(h.Get(prefix + "Remaining")); err == nil && rl.Remaining == nil {
+			rl.Remaining = &n
+			found = true
+		}
+		if n, err := strconv.ParseInt(h.Get(prefix+"Reset"), 10, 64); err == nil && rl.Reset == 0 {
+			// Large values are a Unix time rather than seconds from now
+			if n > 1_000_000_000 {
+				rl.Reset = max(time.Unix(n, 0).Sub(now).Round(time.Second), 0)
+			} else {
+				rl.Reset = time.Duration(n) * time.Second
+			}
+			found = true
+		}
+	}
+	return rl, found
+}
+
+// firstStructuredItem parses the first item of a structured header list such
+// as `"default";r=50;t=30, "burst";r=5`, returning its name and integer parameters
+func firstStructuredItem(value string) (string, map[string]int) {
+	item, _, _ := strings.Cut(value, ",")
+	parts := strings.Split(item, ";")
+	name := strings.Trim(strings.TrimSpace(parts[0]), `"`)
+	params := make(map[string]int)
+	for _, p := range parts[1:] {
+		key, val, ok := strings.Cut(strings.TrimSpace(p), "=")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.Trim(val, `"`)); err == nil {
+			params[strings.ToLower(key)] = n
+		}
+	}
+	return name, params
+}
+
+// rateLimitTransport records the rate-limit headers of every response and
+// logs them at debug level (--log-format with --verbose)
+type rateLimitTransport struct {
+	base http.RoundTripper
+
+	mu   sync.Mutex
+	last *RateLimit
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	rl, ok := parseRateLimit(resp.Header, time.Now())
+	if !ok {
+		return resp, nil
+	}
+
+	t.mu.Lock()
+	t.last = &rl
+	t.mu.Unlock()
+
+	attrs := []any{"method", req.Method, "url", req.URL.String(), "status", resp.StatusCode}
+	if rl.Remaining != nil {
+		attrs = append(attrs, "remaining", *rl.Remaining)
+	}
+	if rl.Limit != nil {
+		attrs = append(attrs, "limit", *rl.Limit)
+	}
+	if rl.Reset > 0 {
+		attrs = append(attrs, "reset", rl.Reset.String())
+	}
+	logging.Logger.Debug("rate limit", attrs...)
+	return resp, nil
+}
+
+// RateLimit returns the rate-limit budget reported by the most recent
+// response, or false if no response carried rate-limit headers
+func (c *Client) RateLimit() (RateLimit, bool) {
+	c.rateLimit.mu.Lock()
+	defer c.rateLimit.mu.Unlock()
+	if c.rateLimit.last == nil {
+		return RateLimit{}, false
+	}
+	return *c.rateLimit.last, true
+}
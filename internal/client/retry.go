@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coollabsio/cloudflare-cli/internal/logging"
+)
+
+// DefaultRetryStatuses are the HTTP statuses retried when no --retry-on list is given
+var DefaultRetryStatuses = []int{429, 500, 502, 503, 504}
+
+// DefaultMaxRetries is how often a request is retried unless max_retries is configured
+const DefaultMaxRetries = 3
+
+const (
+	minRetryDelay = 1 * time.Second
+	maxRetryDelay = 30 * time.Second
+)
+
+// ParseRetryStatuses parses a comma-separated list of HTTP status codes
+func ParseRetryStatuses(s string) ([]int, error) {
+	var statuses []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		code, err := strconv.Atoi(part)
+		if err != nil || code < 400 || code > 599 {
+			return nil, fmt.Errorf("invalid retry status %q (must be an HTTP error status between 400 and 599)", part)
+		}
+		statuses = append(statuses, code)
+	}
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("no retry statuses given (use --no-retry to disable retries)")
+	}
+	return statuses, nil
+}
+
+// retryTransport retries requests that fail with one of the configured statuses,
+// backing off exponentially (or as told by Retry-After) between attempts
+type retryTransport struct {
+	base       http.RoundTripper
+	statuses   map[int]bool
+	maxRetries int
+}
+
+// newRetryTransport wraps base so that the given statuses are retried up to
+// maxRetries times. An empty list disables retries.
+func newRetryTransport(base http.RoundTripper, statuses []int, maxRetries int) *retryTransport {
+	t := &retryTransport{base: base, statuses: make(map[int]bool), maxRetries: maxRetries}
+	for _, s := range statuses {
+		t.statuses[s] = true
+	}
+	return t
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err != nil || !t.statuses[resp.StatusCode] || attempt >= t.maxRetries {
+			return resp, err
+		}
+
+		// The body must be replayable to retry; requests built from a byte
+		// slice or reader (as cloudflare-go does) set GetBody
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		// A server error doesn't mean the request wasn't applied; let the
+		// caller check before it is sent again. A 429 was never processed.
+		if check := beforeRetryCheck(req.Context()); check != nil && resp.StatusCode != http.StatusTooManyRequests {
+			if !check(withBeforeRetry(req.Context(), nil)) {
+				return resp, nil
+			}
+		}
+
+		retryAfter := resp.Header.Get("Retry-After")
+		if retryAfter == "" && resp.StatusCode == http.StatusTooManyRequests {
+			// Without Retry-After, wait for the rate-limit window to reset
+			if rl, ok := parseRateLimit(resp.Header, time.Now()); ok && rl.Reset > 0 {
+				retryAfter = strconv.Itoa(int(rl.Reset.Seconds()))
+			}
+		}
+		delay := retryDelay(attempt, retryAfter)
+		logging.Logger.Info("retrying request", "method", req.Method, "url", req.URL.String(), "status", resp.StatusCode, "attempt", attempt+1, "delay", delay.String())
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, fmt.Errorf("operation aborted during backoff: %w", req.Context().Err())
+		}
+
+		attemptReq = req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+	}
+}
+
+// beforeRetryKey is the context key of the check run before a request is retried
+type beforeRetryKey struct{}
+
+// withBeforeRetry returns a context whose requests are only retried after a
+// server error if check reports that it is safe to send them again. check
+// gets a context without itself, for lookups of its own.
+func withBeforeRetry(ctx context.Context, check func(context.Context) bool) context.Context {
+	return context.WithValue(ctx, beforeRetryKey{}, check)
+}
+
+// beforeRetryCheck returns the check set with withBeforeRetry, or nil
+func beforeRetryCheck(ctx context.Context) func(context.Context) bool {
+	check, _ := ctx.Value(beforeRetryKey{}).(func(context.Context) bool)
+	return check
+}
+
+// retryDelay returns the wait before the next attempt, preferring the
+// server's Retry-After (in seconds) and capping it at maxRetryDelay
+func retryDelay(attempt int, retryAfter string) time.Duration {
+	delay := minRetryDelay << attempt
+	if secs, err := strconv.Atoi(retryAfter); err == nil && secs >= 0 {
+		delay = time.Duration(secs) * time.Second
+	}
+	return min(delay, maxRetryDelay)
+}
+
+// BackoffDelay returns the wait before retry attempt (counting from 0) when
+// there is no Retry-After hint, for retrying work outside the API client
+func BackoffDelay(attempt int) time.Duration {
+	return retryDelay(attempt, "")
+}
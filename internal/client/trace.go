@@ -0,0 +1,104 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Trace is a captured HTTP request/response exchange with the Cloudflare API
+type Trace struct {
+	Method          string
+	URL             string
+	RequestHeaders  http.Header
+	RequestBody     []byte
+	Status          string
+	ResponseHeaders http.Header
+	ResponseBody    []byte
+}
+
+// sensitiveHeaders are redacted from captured traces
+var sensitiveHeaders = []string{"Authorization", "X-Auth-Key", "X-Auth-Email", "X-Auth-User-Service-Key", "Cf-Access-Client-Secret"}
+
+// transport wraps an http.RoundTripper and optionally records each exchange
+type transport struct {
+	base http.RoundTripper
+
+	mu      sync.Mutex
+	tracing bool
+	traces  []Trace
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	tracing := t.tracing
+	t.mu.Unlock()
+
+	if !tracing {
+		return t.base.RoundTrip(req)
+	}
+
+	trace := Trace{
+		Method:         req.Method,
+		URL:            req.URL.String(),
+		RequestHeaders: redactHeaders(req.Header),
+	}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		trace.RequestBody = body
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	trace.Status = resp.Status
+	trace.ResponseHeaders = resp.Header.Clone()
+	trace.ResponseBody = body
+
+	t.mu.Lock()
+	t.traces = append(t.traces, trace)
+	t.mu.Unlock()
+
+	return resp, nil
+}
+
+// redactHeaders returns a copy of the headers with credential values hidden
+func redactHeaders(h http.Header) http.Header {
+	redacted := h.Clone()
+	for _, name := range sensitiveHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "[redacted]")
+		}
+	}
+	return redacted
+}
+
+// EnableTrace starts capturing every HTTP exchange made by the client
+func (c *Client) EnableTrace() {
+	c.transport.mu.Lock()
+	defer c.transport.mu.Unlock()
+	c.transport.tracing = true
+}
+
+// Traces returns the HTTP exchanges captured since EnableTrace was called
+func (c *Client) Traces() []Trace {
+	c.transport.mu.Lock()
+	defer c.transport.mu.Unlock()
+	return append([]Trace(nil), c.transport.traces...)
+}
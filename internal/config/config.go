@@ -1,18 +1,63 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config holds the CLI configuration
 type Config struct {
+	// Version is the schema version of the file (0 or 1 for files written
+	// before profiles; cf config migrate upgrades to CurrentVersion)
+	Version      int    `yaml:"version,omitempty"`
 	APIToken     string `yaml:"api_token,omitempty"`
 	APIKey       string `yaml:"api_key,omitempty"`
 	APIEmail     string `yaml:"api_email,omitempty"`
 	OutputFormat string `yaml:"output_format,omitempty"`
+	// DefaultProxied makes dns create proxy A, AAAA, and CNAME records unless --proxied=false is given
+	DefaultProxied bool `yaml:"default_proxied,omitempty"`
+	// DefaultTTL is the TTL dns create uses when --ttl is not given (0 means auto)
+	DefaultTTL int `yaml:"default_ttl,omitempty"`
+	// MinTTL is the lowest TTL dns create and update accept without --force;
+	// the automatic TTL is always allowed (0 means no policy)
+	MinTTL int `yaml:"min_ttl,omitempty"`
+	// MaxRetries is how often a failed request is retried (0 uses the client default)
+	MaxRetries int `yaml:"max_retries,omitempty"`
+	// Headers are added to every API request; --header values are merged in
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// APIBaseURL replaces the default API endpoint, e.g. for a regional
+	// endpoint or a gateway (overridden by CLOUDFLARE_API_BASE_URL and --api-url)
+	APIBaseURL string `yaml:"api_base_url,omitempty"`
+	// Profiles are named sets of credentials, selected with --profile
+	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+
+	// Profile is the active profile (set from --profile or CLOUDFLARE_PROFILE, never saved)
+	Profile string `yaml:"-"`
+
+	// DryRun makes the client skip mutating API calls (set from --dry-run, never saved)
+	DryRun bool `yaml:"-"`
+	// PrintCurl prints each API request as a curl command (set from --print-curl, never saved)
+	PrintCurl bool `yaml:"-"`
+	// RetryOn lists the HTTP statuses the client retries (nil uses the client default; set from --retry-on)
+	RetryOn []int `yaml:"-"`
+	// NoRetry disables retries entirely (set from --no-retry)
+	NoRetry bool `yaml:"-"`
+
+	// credentialSource records where the active credentials came from
+	credentialSource string
+}
+
+// Profile is a named set of credentials in the config file. When a profile is
+// active its credentials replace the top-level ones.
+type Profile struct {
+	APIToken string `yaml:"api_token,omitempty"`
+	APIKey   string `yaml:"api_key,omitempty"`
+	APIEmail string `yaml:"api_email,omitempty"`
 }
 
 // DefaultConfigPath returns the default config file path
@@ -24,35 +69,125 @@ func DefaultConfigPath() string {
 	return filepath.Join(home, ".cloudflare", "config.yaml")
 }
 
-// Load loads configuration from file and environment variables.
+// ResolvePath returns the config file path to use.
+// An explicit path wins, then CLOUDFLARE_CONFIG / CF_CONFIG, then the default path.
+func ResolvePath(configPath string) string {
+	if configPath != "" {
+		return configPath
+	}
+	if envPath := getEnv("CLOUDFLARE_CONFIG", "CF_CONFIG"); envPath != "" {
+		return envPath
+	}
+	return DefaultConfigPath()
+}
+
+// Load loads configuration from file and environment variables, using the
+// profile named by CLOUDFLARE_PROFILE / CF_PROFILE if one is set.
 // Environment variables take precedence over config file values.
 func Load(configPath string) (*Config, error) {
-	cfg := &Config{}
+	return LoadProfile(configPath, "")
+}
+
+// LoadProfile is Load with an explicit profile; an empty profile falls back to
+// CLOUDFLARE_PROFILE / CF_PROFILE, then to DefaultProfile when the file has no
+// top-level credentials. A profile that is not in the config file
+// leaves the file credentials empty. The result includes the environment
+// (credentials and the API base URL), so it is never saved; see LoadFile.
+func LoadProfile(configPath, profile string) (*Config, error) {
+	cfg := LoadFile(configPath, profile)
+
+	// Environment variables override config file (check multiple env var names).
+	// An explicit value wins over a *_FILE variable pointing at a secret file.
+	for _, e := range []struct {
+		field *string
+		names []string
+	}{
+		{&cfg.APIToken, []string{"CLOUDFLARE_API_TOKEN", "CF_API_TOKEN"}},
+		{&cfg.APIKey, []string{"CLOUDFLARE_API_KEY", "CF_API_KEY"}},
+		{&cfg.APIEmail, []string{"CLOUDFLARE_API_EMAIL", "CF_API_EMAIL"}},
+	} {
+		if val := getEnv(e.names...); val != "" {
+			*e.field = val
+			cfg.credentialSource = "environment"
+			continue
+		}
+		val, err := getEnvFile(e.names...)
+		if err != nil {
+			return nil, err
+		}
+		if val != "" {
+			*e.field = val
+			cfg.credentialSource = "secret file"
+		}
+	}
+
+	if val := getEnv("CLOUDFLARE_API_BASE_URL", "CF_API_BASE_URL"); val != "" {
+		cfg.APIBaseURL = val
+	}
+
+	return cfg, nil
+}
+
+// LoadFile is LoadProfile without the environment: the config file with the
+// profile selected the same way. Commands that change the config file and save
+// it start from this, so credentials and the base URL from the environment or
+// secret files are never written to the file.
+func LoadFile(configPath, profile string) *Config {
+	cfg := ReadFile(ResolvePath(configPath))
+	if cfg.HasCredentials() {
+		cfg.credentialSource = "config file"
+	}
 
-	// Try to load from config file
-	if configPath == "" {
-		configPath = DefaultConfigPath()
+	if profile == "" {
+		profile = getEnv("CLOUDFLARE_PROFILE", "CF_PROFILE")
+	}
+	// A migrated file keeps its credentials in the default profile
+	if _, ok := cfg.Profiles[DefaultProfile]; profile == "" && ok && !cfg.HasCredentials() {
+		profile = DefaultProfile
+	}
+	cfg.Profile = profile
+	if profile != "" {
+		p := cfg.Profiles[profile]
+		cfg.APIToken, cfg.APIKey, cfg.APIEmail = p.APIToken, p.APIKey, p.APIEmail
+		cfg.credentialSource = ""
+		if cfg.HasCredentials() {
+			cfg.credentialSource = fmt.Sprintf("config file, profile %s", profile)
+		}
 	}
+	return cfg
+}
 
+// ReadFile reads the config file without applying environment variables or
+// a profile. A missing or unreadable file gives an empty config, since the
+// config file is optional.
+func ReadFile(configPath string) *Config {
+	cfg := &Config{}
 	if configPath != "" {
 		if data, err := os.ReadFile(configPath); err == nil {
 			_ = yaml.Unmarshal(data, cfg)
 		}
-		// Ignore file read errors - config file is optional
 	}
+	return cfg
+}
 
-	// Environment variables override config file (check multiple env var names)
-	if token := getEnv("CLOUDFLARE_API_TOKEN", "CF_API_TOKEN"); token != "" {
-		cfg.APIToken = token
-	}
-	if key := getEnv("CLOUDFLARE_API_KEY", "CF_API_KEY"); key != "" {
-		cfg.APIKey = key
-	}
-	if email := getEnv("CLOUDFLARE_API_EMAIL", "CF_API_EMAIL"); email != "" {
-		cfg.APIEmail = email
+// CheckFile reads and parses the config file, returning any read or YAML errors.
+// A missing config file is reported via exists=false and is not an error.
+func CheckFile(configPath string) (exists bool, err error) {
+	configPath = ResolvePath(configPath)
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return true, err
 	}
 
-	return cfg, nil
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return true, err
+	}
+	return true, nil
 }
 
 // getEnv returns the first non-empty environment variable from the given names
@@ -65,11 +200,53 @@ func getEnv(names ...string) string {
 	return ""
 }
 
+// getEnvFile reads the first of the <name>_FILE environment variables that is
+// set and returns the contents of the file it points at, trimmed of whitespace
+func getEnvFile(names ...string) (string, error) {
+	for _, name := range names {
+		path := os.Getenv(name + "_FILE")
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s_FILE: %w", name, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return "", nil
+}
+
 // HasCredentials returns true if valid credentials are configured
 func (c *Config) HasCredentials() bool {
 	return c.APIToken != "" || (c.APIKey != "" && c.APIEmail != "")
 }
 
+// ErrNoCredentials is returned when no credentials are configured at all
+var ErrNoCredentials = errors.New("no credentials configured. Set CLOUDFLARE_API_TOKEN or CLOUDFLARE_API_KEY + CLOUDFLARE_API_EMAIL")
+
+// CheckCredentials returns an error describing exactly which credential is missing,
+// or nil if a usable set of credentials is configured
+func (c *Config) CheckCredentials() error {
+	switch {
+	case c.APIToken != "":
+		return nil
+	case c.APIKey != "" && c.APIEmail != "":
+		return nil
+	case c.APIKey != "":
+		return errors.New("API key is set but API email is missing. Set CLOUDFLARE_API_EMAIL (API key auth requires both), or use CLOUDFLARE_API_TOKEN instead")
+	case c.APIEmail != "":
+		return errors.New("API email is set but API key is missing. Set CLOUDFLARE_API_KEY (API key auth requires both), or use CLOUDFLARE_API_TOKEN instead")
+	}
+	if c.Profile != "" {
+		if _, ok := c.Profiles[c.Profile]; !ok {
+			return fmt.Errorf("profile %q is not in the config file. Create it with: cf --profile %s auth save <token>", c.Profile, c.Profile)
+		}
+		return fmt.Errorf("profile %q has no credentials. Set them with: cf --profile %s auth save <token>", c.Profile, c.Profile)
+	}
+	return ErrNoCredentials
+}
+
 // AuthMethod returns a description of the configured auth method
 func (c *Config) AuthMethod() string {
 	if c.APIToken != "" {
@@ -81,10 +258,34 @@ func (c *Config) AuthMethod() string {
 	return "None"
 }
 
-// Save saves the configuration to a file
+// CredentialSource returns where the active credentials were loaded from
+func (c *Config) CredentialSource() string {
+	if c.credentialSource == "" {
+		return "none"
+	}
+	return c.credentialSource
+}
+
+// Save saves the configuration to a file. With an active profile, the
+// credentials are stored under that profile and the top-level credentials in
+// the file are kept as they are; other settings are saved at the top level.
 func (c *Config) Save(configPath string) error {
-	if configPath == "" {
-		configPath = DefaultConfigPath()
+	configPath = ResolvePath(configPath)
+
+	if c.Profile != "" {
+		onDisk := ReadFile(configPath)
+		saved := *c
+		saved.APIToken, saved.APIKey, saved.APIEmail = onDisk.APIToken, onDisk.APIKey, onDisk.APIEmail
+		saved.Profiles = make(map[string]Profile, len(onDisk.Profiles)+1)
+		for name, p := range onDisk.Profiles {
+			saved.Profiles[name] = p
+		}
+		// Saving other settings doesn't create an empty profile
+		_, exists := saved.Profiles[c.Profile]
+		if exists || c.APIToken != "" || c.APIKey != "" || c.APIEmail != "" {
+			saved.Profiles[c.Profile] = Profile{APIToken: c.APIToken, APIKey: c.APIKey, APIEmail: c.APIEmail}
+		}
+		c = &saved
 	}
 
 	// Create directory if it doesn't exist
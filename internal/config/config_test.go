@@ -0,0 +1,230 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// clearEnv unsets every environment variable the config reads, for the
+// duration of the test
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{
+		"CLOUDFLARE_API_TOKEN", "CF_API_TOKEN",
+		"CLOUDFLARE_API_KEY", "CF_API_KEY",
+		"CLOUDFLARE_API_EMAIL", "CF_API_EMAIL",
+		"CLOUDFLARE_API_TOKEN_FILE", "CF_API_TOKEN_FILE",
+		"CLOUDFLARE_API_KEY_FILE", "CF_API_KEY_FILE",
+		"CLOUDFLARE_API_EMAIL_FILE", "CF_API_EMAIL_FILE",
+		"CLOUDFLARE_API_BASE_URL", "CF_API_BASE_URL",
+		"CLOUDFLARE_PROFILE", "CF_PROFILE",
+		"CLOUDFLARE_CONFIG", "CF_CONFIG",
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+// writeConfig writes a config file to a temporary directory and returns its path
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const profilesConfig = `output_format: json
+api_token: top-token
+profiles:
+  work:
+    api_token: work-token
+`
+
+func TestSaveNoProfileKeepsProfiles(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, profilesConfig)
+
+	cfg := LoadFile(path, "")
+	cfg.DefaultTTL = 300
+	if err := cfg.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := ReadFile(path)
+	if saved.APIToken != "top-token" || saved.OutputFormat != "json" || saved.DefaultTTL != 300 {
+		t.Errorf("top level = %q, %q, %d; want top-token, json, 300", saved.APIToken, saved.OutputFormat, saved.DefaultTTL)
+	}
+	if got := saved.Profiles["work"].APIToken; got != "work-token" {
+		t.Errorf("work token = %q, want work-token", got)
+	}
+}
+
+func TestSaveExistingProfile(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, profilesConfig)
+
+	cfg := LoadFile(path, "work")
+	cfg.APIToken = "new-work-token"
+	if err := cfg.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := ReadFile(path)
+	if got := saved.Profiles["work"].APIToken; got != "new-work-token" {
+		t.Errorf("work token = %q, want new-work-token", got)
+	}
+	if saved.APIToken != "top-token" || saved.OutputFormat != "json" {
+		t.Errorf("top level = %q, %q; want top-token, json", saved.APIToken, saved.OutputFormat)
+	}
+}
+
+func TestSaveNewProfile(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, profilesConfig)
+
+	cfg := LoadFile(path, "client-a")
+	if cfg.HasCredentials() {
+		t.Fatalf("new profile has credentials %q", cfg.APIToken)
+	}
+	cfg.APIToken = "client-a-token"
+	if err := cfg.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := ReadFile(path)
+	if got := saved.Profiles["client-a"].APIToken; got != "client-a-token" {
+		t.Errorf("client-a token = %q, want client-a-token", got)
+	}
+	if got := saved.Profiles["work"].APIToken; got != "work-token" {
+		t.Errorf("work token = %q, want work-token", got)
+	}
+	if saved.APIToken != "top-token" {
+		t.Errorf("top-level token = %q, want top-token", saved.APIToken)
+	}
+}
+
+func TestSaveSettingDoesNotCreateProfile(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, profilesConfig)
+
+	cfg := LoadFile(path, "missing")
+	cfg.OutputFormat = "table"
+	if err := cfg.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := ReadFile(path)
+	if _, ok := saved.Profiles["missing"]; ok {
+		t.Error("saving a setting created an empty profile")
+	}
+	if saved.OutputFormat != "table" {
+		t.Errorf("output_format = %q, want table", saved.OutputFormat)
+	}
+}
+
+func TestLoadFileIgnoresEnvironment(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, profilesConfig)
+	t.Setenv("CLOUDFLARE_API_TOKEN", "env-token")
+
+	cfg := LoadFile(path, "work")
+	cfg.OutputFormat = "table"
+	if err := cfg.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ReadFile(path).Profiles["work"].APIToken; got != "work-token" {
+		t.Errorf("work token = %q, want work-token (the environment token was saved)", got)
+	}
+	loaded, err := LoadProfile(path, "work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.APIToken != "env-token" || loaded.CredentialSource() != "environment" {
+		t.Errorf("LoadProfile token = %q from %s, want env-token from environment", loaded.APIToken, loaded.CredentialSource())
+	}
+}
+
+func TestLoadFileIgnoresSecretFile(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, "default_ttl: 60\n")
+	secret := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(secret, []byte("secret-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CLOUDFLARE_API_TOKEN_FILE", secret)
+
+	cfg := LoadFile(path, "")
+	cfg.DefaultTTL = 300
+	if err := cfg.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ReadFile(path).APIToken; got != "" {
+		t.Errorf("saved api_token = %q, want none (the secret file was written to the config)", got)
+	}
+	loaded, err := LoadProfile(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.APIToken != "secret-token" || loaded.CredentialSource() != "secret file" {
+		t.Errorf("LoadProfile token = %q from %s, want secret-token from secret file", loaded.APIToken, loaded.CredentialSource())
+	}
+}
+
+func TestLoadFileIgnoresBaseURLEnvironment(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, "")
+	t.Setenv("CLOUDFLARE_API_BASE_URL", "https://eu.example.com/client/v4")
+
+	cfg := LoadFile(path, "")
+	cfg.OutputFormat = "json"
+	if err := cfg.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ReadFile(path).APIBaseURL; got != "" {
+		t.Errorf("saved api_base_url = %q, want none (the environment override was written to the config)", got)
+	}
+	loaded, err := LoadProfile(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.APIBaseURL != "https://eu.example.com/client/v4" {
+		t.Errorf("LoadProfile api_base_url = %q, want the environment override", loaded.APIBaseURL)
+	}
+}
+
+func TestCheckCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{"token", Config{APIToken: "token"}, ""},
+		{"key and email", Config{APIKey: "key", APIEmail: "user@example.com"}, ""},
+		{"token with partial key auth", Config{APIToken: "token", APIKey: "key"}, ""},
+		{"key only", Config{APIKey: "key"}, "API email is missing"},
+		{"email only", Config{APIEmail: "user@example.com"}, "API key is missing"},
+		{"nothing", Config{}, ErrNoCredentials.Error()},
+		{"missing profile", Config{Profile: "work"}, `profile "work" is not in the config file`},
+		{"empty profile", Config{Profile: "work", Profiles: map[string]Profile{"work": {}}}, `profile "work" has no credentials`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.CheckCredentials()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckCredentials() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckCredentials() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentVersion is the config schema version written by Migrate.
+// Version 2 keeps credentials in profiles instead of at the top level.
+const CurrentVersion = 2
+
+// DefaultProfile is the profile Migrate moves top-level credentials into. It is
+// used when no profile is selected and the file has no top-level credentials.
+const DefaultProfile = "default"
+
+// MigrateResult describes what Migrate changed
+type MigrateResult struct {
+	Path    string
+	Backup  string
+	Version int
+	Changes []string
+}
+
+// Migrate upgrades the config file to CurrentVersion, copying the original to
+// a timestamped backup next to it first. A file that is already current is
+// left alone and gives no changes. With dryRun the changes are only reported.
+func Migrate(configPath string, dryRun bool) (*MigrateResult, error) {
+	configPath = ResolvePath(configPath)
+	result := &MigrateResult{Path: configPath, Version: CurrentVersion}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no config file at %s", configPath)
+		}
+		return nil, err
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+
+	// Unknown keys would be lost on rewrite; say so (they stay in the backup)
+	known := fileKeys()
+	var unknown []string
+	for key := range raw {
+		if !slices.Contains(known, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	slices.Sort(unknown)
+	for _, key := range unknown {
+		result.Changes = append(result.Changes, fmt.Sprintf("removed unknown key %q", key))
+	}
+
+	if cfg.Version < 2 {
+		moved, err := moveCredentialsToProfile(&cfg)
+		if err != nil {
+			return nil, err
+		}
+		if len(moved) > 0 {
+			result.Changes = append(result.Changes, fmt.Sprintf("moved top-level %s into profile %q", strings.Join(moved, ", "), DefaultProfile))
+		}
+	}
+	if cfg.Version < CurrentVersion {
+		result.Changes = append(result.Changes, fmt.Sprintf("set version %d (was %d)", CurrentVersion, max(cfg.Version, 1)))
+		cfg.Version = CurrentVersion
+	} else if cfg.Version > CurrentVersion {
+		return nil, fmt.Errorf("config version %d is newer than this cf supports (%d); update cf", cfg.Version, CurrentVersion)
+	}
+
+	if len(result.Changes) == 0 || dryRun {
+		return result, nil
+	}
+
+	out, err := yaml.Marshal(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	result.Backup = fmt.Sprintf("%s.%s.bak", configPath, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(result.Backup, data, 0600); err != nil {
+		return nil, fmt.Errorf("failed to back up config: %w", err)
+	}
+	if err := os.WriteFile(configPath, out, 0600); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// moveCredentialsToProfile moves the top-level credentials into DefaultProfile
+// and returns the keys it moved. An existing default profile with other
+// credentials is not overwritten.
+func moveCredentialsToProfile(cfg *Config) ([]string, error) {
+	top := Profile{APIToken: cfg.APIToken, APIKey: cfg.APIKey, APIEmail: cfg.APIEmail}
+	if top == (Profile{}) {
+		return nil, nil
+	}
+	if p, ok := cfg.Profiles[DefaultProfile]; ok && p != (Profile{}) && p != top {
+		return nil, fmt.Errorf("profile %q already has other credentials; move the top-level credentials by hand", DefaultProfile)
+	}
+
+	var moved []string
+	for _, f := range []struct {
+		key   string
+		value string
+	}{{"api_token", top.APIToken}, {"api_key", top.APIKey}, {"api_email", top.APIEmail}} {
+		if f.value != "" {
+			moved = append(moved, f.key)
+		}
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]Profile)
+	}
+	cfg.Profiles[DefaultProfile] = top
+	cfg.APIToken, cfg.APIKey, cfg.APIEmail = "", "", ""
+	return moved, nil
+}
+
+// fileKeys returns the top-level keys of the config file, from the yaml tags of Config
+func fileKeys() []string {
+	var keys []string
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,42 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Logger is the package-level logger for operational events.
+// It discards everything until Setup is called with a log format.
+var Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// Setup configures Logger to write to w in the given format ("text" or "json").
+// An empty format leaves logging disabled. With verbose, debug events such as
+// the rate-limit budget of each response are logged as well.
+func Setup(format string, verbose bool, w io.Writer) error {
+	opts := &slog.HandlerOptions{}
+	if verbose {
+		opts.Level = slog.LevelDebug
+	}
+	switch format {
+	case "":
+		return nil
+	case "text":
+		Logger = slog.New(slog.NewTextHandler(w, opts))
+	case "json":
+		Logger = slog.New(slog.NewJSONHandler(w, opts))
+	default:
+		return fmt.Errorf("invalid log format: %s (must be 'text' or 'json')", format)
+	}
+	return nil
+}
+
+// PrintfLogger adapts Logger to the Printf-style logger used by cloudflare-go,
+// which reports retries and backoff waits
+type PrintfLogger struct{}
+
+// Printf logs a formatted message at info level
+func (PrintfLogger) Printf(format string, v ...interface{}) {
+	Logger.Info(strings.TrimSpace(fmt.Sprintf(format, v...)), "source", "cloudflare-go")
+}
@@ -5,52 +5,231 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
+	"text/template"
+	"unicode/utf8"
 )
 
 // Format represents the output format
 type Format string
 
 const (
-	FormatTable Format = "table"
-	FormatJSON  Format = "json"
+	FormatTable    Format = "table"
+	FormatJSON     Format = "json"
+	FormatTemplate Format = "template"
+	FormatEnv      Format = "env"
 )
 
+// defaultEnvPrefix is the variable name prefix used by env output unless a
+// command sets its own
+const defaultEnvPrefix = "CF"
+
 // Writer handles output formatting
 type Writer struct {
-	format Format
-	out    io.Writer
+	format    Format
+	out       io.Writer
+	compact   bool
+	template  *template.Template
+	envPrefix string
+
+	// strict validates typed JSON output against its schema (--strict-json)
+	strict bool
+	// maxColWidth caps table cells at this many characters, or 0 for no cap
+	maxColWidth int
+
+	// tee receives the JSON form of the output as well (--tee), or is nil
+	tee      io.Writer
+	teeTyped bool
+	teeErr   error
 }
 
 // NewWriter creates a new output writer
 func NewWriter(format Format) *Writer {
 	return &Writer{
-		format: format,
-		out:    os.Stdout,
+		format:    format,
+		out:       os.Stdout,
+		envPrefix: defaultEnvPrefix,
+	}
+}
+
+// SetEnvPrefix sets the prefix of the variable names written by env output,
+// e.g. "CF_RECORD" for CF_RECORD_ID, CF_RECORD_CONTENT, ...
+func (w *Writer) SetEnvPrefix(prefix string) {
+	w.envPrefix = prefix
+}
+
+// SetTee makes the writer also write the JSON form of everything it outputs
+// to tee, so one run gives both a table on screen and JSON in a file
+func (w *Writer) SetTee(tee io.Writer) {
+	w.tee = tee
+}
+
+// TeeJSON writes typed data to the tee as -o json would print it. Commands
+// whose JSON output is not their table call it before writing the table; the
+// table is then not written to the tee again. In JSON mode it does nothing,
+// since WriteJSON tees the real output.
+func (w *Writer) TeeJSON(data interface{}) {
+	if w.tee == nil || w.format == FormatJSON {
+		return
 	}
+	w.teeTyped = true
+	w.writeTee(data)
+}
+
+// TeeErr returns the first error that occurred writing to the tee
+func (w *Writer) TeeErr() error {
+	return w.teeErr
+}
+
+// writeTee encodes data to the tee, keeping the first error
+func (w *Writer) writeTee(data interface{}) {
+	if w.tee == nil || w.teeErr != nil {
+		return
+	}
+	if err := NewJSONEncoder(w.tee, w.compact).Encode(data); err != nil {
+		w.teeErr = err
+	}
+}
+
+// SetTemplate sets the Go template used by FormatTemplate
+func (w *Writer) SetTemplate(tmpl *template.Template) {
+	w.template = tmpl
+}
+
+// ParseTemplate parses a Go text/template for template output
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// IsTemplate reports whether output is rendered through a Go template
+func (w *Writer) IsTemplate() bool {
+	return w.format == FormatTemplate
 }
 
-// WriteTable writes data as a table or JSON depending on format
+// WriteTable writes data as a table or JSON depending on format.
+// In template mode each row is rendered as a map keyed by header.
 func (w *Writer) WriteTable(headers []string, rows [][]string) error {
-	if w.format == FormatJSON {
+	if w.format != FormatJSON && !w.teeTyped {
+		w.writeTee(tableItems(headers, rows))
+	}
+	switch w.format {
+	case FormatJSON:
 		return w.writeTableAsJSON(headers, rows)
+	case FormatEnv:
+		return w.writeTableAsEnv(headers, rows)
+	case FormatTemplate:
+		return w.writeTemplate(tableItems(headers, rows))
 	}
 	return w.writeASCIITable(headers, rows)
 }
 
+// WriteTemplate renders typed data through the template. Slices are rendered
+// one element per line; anything else is rendered once.
+func (w *Writer) WriteTemplate(data interface{}) error {
+	if !w.teeTyped {
+		w.writeTee(data)
+	}
+	return w.writeTemplate(data)
+}
+
+func (w *Writer) writeTemplate(data interface{}) error {
+	if w.template == nil {
+		return fmt.Errorf("no template set (use --template or --template-file)")
+	}
+
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Slice {
+		for i := 0; i < v.Len(); i++ {
+			if err := w.executeTemplate(v.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return w.executeTemplate(data)
+}
+
+func (w *Writer) executeTemplate(item interface{}) error {
+	if err := w.template.Execute(w.out, item); err != nil {
+		return fmt.Errorf("template execution failed: %w", err)
+	}
+	_, err := fmt.Fprintln(w.out)
+	return err
+}
+
+// SetCompact switches JSON output between indented (default) and single-line
+func (w *Writer) SetCompact(compact bool) {
+	w.compact = compact
+}
+
+// SetStrict makes JSON output be checked against its bundled JSON schema,
+// failing instead of printing output that does not match or has no schema
+func (w *Writer) SetStrict(strict bool) {
+	w.strict = strict
+}
+
 // WriteJSON writes data as JSON
 func (w *Writer) WriteJSON(data interface{}) error {
-	enc := json.NewEncoder(w.out)
-	enc.SetIndent("", "  ")
-	return enc.Encode(data)
+	if w.strict {
+		if err := validateStrict(data); err != nil {
+			return err
+		}
+	}
+	w.writeTee(data)
+	return NewJSONEncoder(w.out, w.compact).Encode(data)
+}
+
+// writeJSONAs is WriteJSON for the writer's own untyped outputs (messages and
+// table rows), which --strict-json checks against the named schema
+func (w *Writer) writeJSONAs(schemaName string, data interface{}) error {
+	if w.strict {
+		if err := validateNamed(data, schemaName); err != nil {
+			return err
+		}
+	}
+	w.writeTee(data)
+	return NewJSONEncoder(w.out, w.compact).Encode(data)
+}
+
+// NewJSONEncoder returns a JSON encoder that indents with two spaces unless compact is set
+func NewJSONEncoder(out io.Writer, compact bool) *json.Encoder {
+	enc := json.NewEncoder(out)
+	if !compact {
+		enc.SetIndent("", "  ")
+	}
+	return enc
 }
 
 // WriteSuccess writes a success message
 func (w *Writer) WriteSuccess(msg string) {
 	if w.format == FormatJSON {
-		w.WriteJSON(map[string]string{"status": "success", "message": msg})
+		w.writeJSONAs("message", map[string]string{"status": "success", "message": msg})
 	} else {
+		if !w.teeTyped {
+			w.writeTee(map[string]string{"status": "success", "message": msg})
+		}
+		fmt.Fprintln(w.out, msg)
+	}
+}
+
+// WriteNote writes a line for people reading table output, such as a page
+// footer or a summary after a table. Env and template output leave it out so
+// they stay machine-readable, and JSON output carries the data instead. The
+// tee gets it as a message unless the command teed its own typed data.
+func (w *Writer) WriteNote(msg string) {
+	if w.format == FormatJSON {
+		return
+	}
+	if !w.teeTyped {
+		w.writeTee(map[string]string{"status": "info", "message": strings.TrimSpace(msg)})
+	}
+	if w.format == FormatTable {
 		fmt.Fprintln(w.out, msg)
 	}
 }
@@ -64,16 +243,42 @@ func (w *Writer) WriteError(err error) {
 	}
 }
 
+// SetMaxColWidth truncates table cells longer than n characters with an
+// ellipsis; 0 turns truncation off. Other formats keep the full content.
+func (w *Writer) SetMaxColWidth(n int) {
+	w.maxColWidth = n
+}
+
+// truncateCell shortens s to at most n runes, ending in an ellipsis
+func truncateCell(s string, n int) string {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:n-1]) + "…"
+}
+
 func (w *Writer) writeASCIITable(headers []string, rows [][]string) error {
-	// Calculate column widths
+	if w.maxColWidth > 0 {
+		truncated := make([][]string, len(rows))
+		for i, row := range rows {
+			truncated[i] = make([]string, len(row))
+			for j, cell := range row {
+				truncated[i][j] = truncateCell(cell, w.maxColWidth)
+			}
+		}
+		rows = truncated
+	}
+
+	// Calculate column widths in runes, as fmt pads
 	widths := make([]int, len(headers))
 	for i, h := range headers {
-		widths[i] = len(h)
+		widths[i] = utf8.RuneCountInString(h)
 	}
 	for _, row := range rows {
 		for i, cell := range row {
-			if i < len(widths) && len(cell) > widths[i] {
-				widths[i] = len(cell)
+			if n := utf8.RuneCountInString(cell); i < len(widths) && n > widths[i] {
+				widths[i] = n
 			}
 		}
 	}
@@ -100,7 +305,13 @@ func (w *Writer) writeASCIITable(headers []string, rows [][]string) error {
 }
 
 func (w *Writer) writeTableAsJSON(headers []string, rows [][]string) error {
-	var result []map[string]string
+	return w.writeJSONAs("table", tableItems(headers, rows))
+}
+
+// tableItems turns table rows into maps keyed by header, as used by JSON and
+// template output
+func tableItems(headers []string, rows [][]string) []map[string]string {
+	var items []map[string]string
 	for _, row := range rows {
 		item := make(map[string]string)
 		for i, header := range headers {
@@ -108,9 +319,42 @@ func (w *Writer) writeTableAsJSON(headers []string, rows [][]string) error {
 				item[header] = row[i]
 			}
 		}
-		result = append(result, item)
+		items = append(items, item)
+	}
+	return items
+}
+
+// writeTableAsEnv writes a single row as shell variable assignments that can
+// be eval'd. Env output has no way to represent several results.
+func (w *Writer) writeTableAsEnv(headers []string, rows [][]string) error {
+	if len(rows) != 1 {
+		return fmt.Errorf("env output needs exactly one result, got %d (use -o json for lists)", len(rows))
 	}
-	return w.WriteJSON(result)
+	for i, header := range headers {
+		value := ""
+		if i < len(rows[0]) {
+			value = rows[0][i]
+		}
+		fmt.Fprintf(w.out, "%s=%s\n", EnvName(w.envPrefix, header), ShellQuote(value))
+	}
+	return nil
+}
+
+// EnvName builds an environment variable name from a prefix and a field name,
+// uppercasing it and replacing anything that isn't a letter or digit with '_'
+func EnvName(prefix, field string) string {
+	name := strings.ToUpper(prefix + "_" + field)
+	return strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// ShellQuote single-quotes s for POSIX shells
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
 }
 
 // FormatBool formats a boolean for display
@@ -133,3 +377,28 @@ func FormatTTL(ttl int) string {
 	}
 	return strconv.Itoa(ttl)
 }
+
+// ParseTTL parses a TTL as accepted on the command line: "auto" (or 1) for
+// automatic, or a number of seconds between 60 and 86400. It accepts
+// everything FormatTTL produces.
+func ParseTTL(s string) (int, error) {
+	if strings.EqualFold(s, "auto") {
+		return 1, nil
+	}
+	ttl, err := strconv.Atoi(s)
+	if err != nil || (ttl != 1 && (ttl < 60 || ttl > 86400)) {
+		return 0, fmt.Errorf("invalid TTL %q (must be 'auto' or between 60 and 86400 seconds)", s)
+	}
+	return ttl, nil
+}
+
+// MaskSecret redacts a credential, keeping only the last 4 characters visible
+func MaskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	if len(secret) <= 4 {
+		return strings.Repeat("*", len(secret))
+	}
+	return strings.Repeat("*", 8) + secret[len(secret)-4:]
+}
@@ -0,0 +1,68 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestWriteNote(t *testing.T) {
+	tests := []struct {
+		format  Format
+		wantOut string
+		wantTee string
+	}{
+		{FormatTable, "\n\nShowing 1–1 of 3\n", `"message": "Showing 1–1 of 3"`},
+		{FormatEnv, "CF_NAME='www'\n", `"message": "Showing 1–1 of 3"`},
+		{FormatTemplate, "www\n", `"message": "Showing 1–1 of 3"`},
+		{FormatJSON, "[\n  {\n    \"NAME\": \"www\"\n  }\n]\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf, tee bytes.Buffer
+			w := NewWriter(tt.format)
+			w.out = &buf
+			w.SetTee(&tee)
+			w.SetTemplate(template.Must(ParseTemplate("{{.NAME}}")))
+
+			if err := w.WriteTable([]string{"NAME"}, [][]string{{"www"}}); err != nil {
+				t.Fatal(err)
+			}
+			w.WriteNote("\nShowing 1–1 of 3")
+
+			got := buf.String()
+			if tt.format == FormatTable {
+				// Only the end of the table is compared, without its padding
+				got = got[strings.LastIndex(got, "www")+len("www"):]
+				got = strings.TrimLeft(got, " ")
+			}
+			if got != tt.wantOut {
+				t.Errorf("output = %q, want %q", got, tt.wantOut)
+			}
+			if tt.wantTee == "" {
+				if strings.Contains(tee.String(), "Showing") {
+					t.Errorf("tee has the note in JSON mode:\n%s", tee.String())
+				}
+			} else if !strings.Contains(tee.String(), tt.wantTee) {
+				t.Errorf("tee = %s, want it to contain %s", tee.String(), tt.wantTee)
+			}
+		})
+	}
+}
+
+func TestWriteNoteAfterTypedTee(t *testing.T) {
+	var buf, tee bytes.Buffer
+	w := NewWriter(FormatTable)
+	w.out = &buf
+	w.SetTee(&tee)
+
+	w.TeeJSON(map[string]int{"count": 1})
+	w.WriteNote("1 record")
+	if strings.Contains(tee.String(), "1 record") {
+		t.Errorf("note added to typed tee output:\n%s", tee.String())
+	}
+	if buf.String() != "1 record\n" {
+		t.Errorf("output = %q, want the note", buf.String())
+	}
+}
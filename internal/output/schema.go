@@ -0,0 +1,265 @@
+package output
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// schemaFiles are the JSON schemas of the typed outputs checked by --strict-json
+//
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+// schemaTypes maps the Go type names of JSON outputs to their schema. Every
+// value given to WriteJSON needs an entry here under --strict-json.
+var schemaTypes = map[string]string{
+	"DNSRecord":          "record",
+	"Zone":               "zone",
+	"Account":            "account",
+	"AccessRule":         "access_rule",
+	"ZoneAnalytics":      "zone_analytics",
+	"ZoneUsage":          "zone_usage",
+	"authListEntry":      "auth_list_entry",
+	"configMigration":    "config_migration",
+	"configValidation":   "config_validation",
+	"countResult":        "count",
+	"delegationCheck":    "delegation_check",
+	"doctorReport":       "doctor_report",
+	"dryRunRecord":       "dry_run_record",
+	"dryRunRecords":      "dry_run_records",
+	"duplicateGroup":     "duplicate_group",
+	"exportAllResult":    "export_all_result",
+	"exportManifest":     "export_manifest",
+	"proxyAudit":         "proxy_audit",
+	"rateLimitResult":    "rate_limit",
+	"recordDiff":         "record_diff",
+	"recordExists":       "record_exists",
+	"recordGetResult":    "record_get_result",
+	"recordGroups":       "record_groups",
+	"recordVerification": "record_verification",
+	"txtExpansion":       "txt_expansion",
+	"updateResult":       "update_result",
+	"zoneCreateResult":   "zone_create_result",
+	"zoneDetails":        "zone_details",
+	"zoneFieldRow":       "zone_fields",
+	"zoneGetResult":      "zone_get_result",
+	"zoneNSCheck":        "zone_details",
+	"zoneNameServers":    "zone_nameservers",
+	"zonePlans":          "zone_plans",
+}
+
+// schema is the subset of JSON Schema the bundled schemas use. $ref names
+// another bundled schema file, e.g. "record.json".
+type schema struct {
+	Ref                  string                `json:"$ref"`
+	Type                 schemaType            `json:"type"`
+	Required             []string              `json:"required"`
+	Properties           map[string]*schema    `json:"properties"`
+	AdditionalProperties *additionalProperties `json:"additionalProperties"`
+	Items                *schema               `json:"items"`
+}
+
+// additionalProperties is either a boolean or a schema for the values of
+// properties not listed in "properties"
+type additionalProperties struct {
+	allowed bool
+	schema  *schema
+}
+
+func (a *additionalProperties) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.allowed); err == nil {
+		return nil
+	}
+	a.allowed = true
+	return json.Unmarshal(data, &a.schema)
+}
+
+// schemaType is a schema's "type", either a single type or a list of them
+type schemaType []string
+
+func (t *schemaType) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaType{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// loadSchema reads a bundled schema by name, e.g. "record"
+func loadSchema(name string) (*schema, error) {
+	data, err := schemaFiles.ReadFile("schemas/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("no JSON schema %q: %w", name, err)
+	}
+	var s schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema %q: %w", name, err)
+	}
+	return &s, nil
+}
+
+// schemaFor returns the schema for data, wrapped in an array schema for
+// slices, or an error if data is not one of the outputs with a schema
+func schemaFor(data interface{}) (*schema, string, error) {
+	t := reflect.TypeOf(data)
+	if t == nil {
+		return nil, "", fmt.Errorf("strict JSON: no schema for a null output")
+	}
+	array := false
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		if t.Kind() == reflect.Slice {
+			if array {
+				return nil, "", fmt.Errorf("strict JSON: no schema for %s output", reflect.TypeOf(data))
+			}
+			array = true
+		}
+		t = t.Elem()
+	}
+	name, ok := schemaTypes[t.Name()]
+	if !ok {
+		return nil, "", fmt.Errorf("strict JSON: no schema for %s output", reflect.TypeOf(data))
+	}
+	s, err := loadSchema(name)
+	if err != nil || !array {
+		return s, name, err
+	}
+	// A nil slice encodes as null
+	return &schema{Type: schemaType{"array", "null"}, Items: s}, name, nil
+}
+
+// validateStrict encodes data and checks the result against its schema. Data
+// without a schema is an error, so the flag covers every JSON output.
+func validateStrict(data interface{}) error {
+	s, name, err := schemaFor(data)
+	if err != nil {
+		return err
+	}
+	return validateAgainst(data, s, name)
+}
+
+// validateNamed checks data against the bundled schema called name, for
+// outputs of the writer itself that have no type of their own
+func validateNamed(data interface{}, name string) error {
+	s, err := loadSchema(name)
+	if err != nil {
+		return err
+	}
+	return validateAgainst(data, s, name)
+}
+
+// validateAgainst encodes data and checks the result against s
+func validateAgainst(data interface{}, s *schema, name string) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("strict JSON: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(encoded))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return fmt.Errorf("strict JSON: %w", err)
+	}
+	if err := s.validate(value, name); err != nil {
+		return fmt.Errorf("strict JSON: output does not match the %s schema: %w", name, err)
+	}
+	return nil
+}
+
+// validate checks value, decoded with UseNumber, against the schema. path
+// names value in errors.
+func (s *schema) validate(value interface{}, path string) error {
+	if s.Ref != "" {
+		ref, err := loadSchema(strings.TrimSuffix(s.Ref, ".json"))
+		if err != nil {
+			return err
+		}
+		return ref.validate(value, path)
+	}
+	if len(s.Type) > 0 {
+		actual := jsonType(value)
+		matched := false
+		for _, t := range s.Type {
+			if t == actual || (t == "number" && actual == "integer") {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s: expected %s, got %s", path, strings.Join(s.Type, " or "), actual)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, field := range s.Required {
+			if _, ok := v[field]; !ok {
+				return fmt.Errorf("%s: missing field %q", path, field)
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			prop, ok := s.Properties[k]
+			if !ok {
+				if s.AdditionalProperties == nil {
+					continue
+				}
+				if !s.AdditionalProperties.allowed {
+					return fmt.Errorf("%s: unexpected field %q", path, k)
+				}
+				prop = s.AdditionalProperties.schema
+				if prop == nil {
+					continue
+				}
+			}
+			if err := prop.validate(v[k], path+"."+k); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				if err := s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// jsonType returns the JSON Schema type of a value decoded with UseNumber
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,109 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// zoneCreateResult and countResult stand in for command outputs, which
+// schemaTypes finds by type name
+type zoneCreateResult struct {
+	Zone  string `json:"zone"`
+	Error string `json:"error,omitempty"`
+}
+
+type countResult struct {
+	Count string `json:"count"`
+}
+
+type unknownOutput struct {
+	Name string `json:"name"`
+}
+
+func TestSchemaFilesLoad(t *testing.T) {
+	seen := make(map[string]bool)
+	for typeName, name := range schemaTypes {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if _, err := loadSchema(name); err != nil {
+			t.Errorf("%s: %v", typeName, err)
+		}
+	}
+	for _, name := range []string{"message", "table"} {
+		if _, err := loadSchema(name); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestStrictJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    interface{}
+		wantErr string
+	}{
+		{"matching output", zoneCreateResult{Zone: "example.com"}, ""},
+		{"slice of outputs", []zoneCreateResult{{Zone: "a.example"}, {Zone: "b.example", Error: "failed"}}, ""},
+		{"nil slice", []zoneCreateResult(nil), ""},
+		{"wrong field type", countResult{Count: "3"}, `count: expected integer, got string`},
+		{"no schema", unknownOutput{Name: "x"}, "no schema for output.unknownOutput output"},
+		{"untyped map", map[string]string{"a": "b"}, "no schema"},
+		{"null output", nil, "no schema for a null output"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(FormatJSON)
+			w.out = &buf
+			w.SetStrict(true)
+
+			err := w.WriteJSON(tt.data)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("WriteJSON() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("WriteJSON() error = %v, want %q", err, tt.wantErr)
+			}
+			if buf.Len() > 0 {
+				t.Errorf("output written despite the error: %s", buf.String())
+			}
+		})
+	}
+}
+
+func TestStrictJSONRef(t *testing.T) {
+	s := &schema{Ref: "record.json"}
+	valid := map[string]interface{}{
+		"ID": "1", "Type": "A", "Name": "www.example.com", "Content": "192.0.2.1", "TTL": 1,
+		"Proxied": false, "Proxiable": true, "Priority": nil, "Comment": "", "Tags": nil,
+	}
+	if err := validateAgainst(valid, s, "record"); err != nil {
+		t.Errorf("valid record: %v", err)
+	}
+
+	delete(valid, "TTL")
+	if err := validateAgainst(valid, s, "record"); err == nil || !strings.Contains(err.Error(), `missing field "TTL"`) {
+		t.Errorf("record without TTL: error = %v, want a missing field error", err)
+	}
+}
+
+func TestStrictJSONWriterOutputs(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(FormatJSON)
+	w.out = &buf
+	w.SetStrict(true)
+
+	w.WriteSuccess("done")
+	if err := w.WriteTable([]string{"NAME", "TTL"}, [][]string{{"www", "Auto"}}); err != nil {
+		t.Errorf("WriteTable() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"message": "done"`) || !strings.Contains(buf.String(), `"NAME": "www"`) {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
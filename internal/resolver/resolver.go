@@ -0,0 +1,292 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// LookupNS returns the nameservers currently delegated for a domain, as seen
+// by the system resolver. Names are lowercased, without trailing dot, and sorted.
+func LookupNS(ctx context.Context, domain string) ([]string, error) {
+	records, err := net.DefaultResolver.LookupNS(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("NS lookup for %s failed: %w", domain, err)
+	}
+
+	var result []string
+	for _, ns := range records {
+		result = append(result, Normalize(ns.Host))
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// LookupParentNS returns the nameservers the parent zone delegates a domain
+// to, asking the parent's nameservers directly instead of the system
+// resolver, so cached or stale answers don't hide a registrar change. Names
+// are lowercased, without trailing dot, and sorted.
+func LookupParentNS(ctx context.Context, domain string) ([]string, error) {
+	domain = Normalize(domain)
+	_, parent, ok := strings.Cut(domain, ".")
+	if !ok {
+		return nil, fmt.Errorf("%s has no parent zone", domain)
+	}
+	parentNS, err := net.DefaultResolver.LookupNS(ctx, parent)
+	if err != nil {
+		return nil, fmt.Errorf("NS lookup for parent zone %s failed: %w", parent, err)
+	}
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(domain), dns.TypeNS)
+	msg.RecursionDesired = false
+	var lastErr error
+	for _, ns := range parentNS {
+		resp, _, err := new(dns.Client).ExchangeContext(ctx, msg, net.JoinHostPort(ns.Host, "53"))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		// The delegation is in the authority section of a referral, or in the
+		// answer if the parent also serves the child zone
+		var result []string
+		for _, rr := range append(resp.Answer, resp.Ns...) {
+			if v, ok := rr.(*dns.NS); ok && Normalize(v.Hdr.Name) == domain {
+				result = append(result, Normalize(v.Ns))
+			}
+		}
+		if len(result) == 0 {
+			return nil, fmt.Errorf("parent zone %s has no delegation for %s", parent, domain)
+		}
+		sort.Strings(result)
+		return result, nil
+	}
+	return nil, fmt.Errorf("NS lookup for %s at parent zone %s failed: %w", domain, parent, lastErr)
+}
+
+// Normalize lowercases a hostname and strips any trailing dot
+func Normalize(host string) string {
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+// CNAMEResult is the outcome of following a CNAME chain
+type CNAMEResult struct {
+	// Chain lists every name visited, starting with the first one
+	Chain []string
+	// Target is the final name in the chain (empty if unresolved or looping)
+	Target string
+	// Addrs are the addresses the final name resolves to
+	Addrs []string
+	// Loop is set when the chain revisits a name or exceeds the depth limit
+	Loop bool
+}
+
+// FollowCNAME follows the CNAME chain starting at host one hop at a time, up to
+// maxDepth hops, and resolves the final name to its addresses. Without
+// /etc/resolv.conf Go's resolver only gives the end of a chain, so the hops in
+// between are missing from Chain.
+func FollowCNAME(ctx context.Context, host string, maxDepth int) (CNAMEResult, error) {
+	var result CNAMEResult
+	seen := make(map[string]bool)
+	name := Normalize(host)
+	for depth := 0; ; depth++ {
+		result.Chain = append(result.Chain, name)
+		if seen[name] || depth > maxDepth {
+			result.Loop = true
+			return result, nil
+		}
+		seen[name] = true
+
+		next, err := lookupCNAME(ctx, name)
+		if err != nil {
+			return result, err
+		}
+		if next == "" {
+			break
+		}
+		name = next
+	}
+
+	result.Target = name
+	addrs, err := net.DefaultResolver.LookupHost(ctx, name)
+	if err != nil {
+		return result, fmt.Errorf("lookup for %s failed: %w", name, err)
+	}
+	sort.Strings(addrs)
+	result.Addrs = addrs
+	return result, nil
+}
+
+// Query asks the system resolver for records of the given type at name and
+// returns their data in Cloudflare's content format: addresses for A/AAAA,
+// hostnames (lowercase, no trailing dot) for CNAME/MX/NS/PTR, and the joined
+// strings for TXT
+func Query(ctx context.Context, name, recordType string) ([]string, error) {
+	qtype, ok := dns.StringToType[strings.ToUpper(recordType)]
+	if !ok {
+		return nil, fmt.Errorf("unknown record type %s", recordType)
+	}
+	result, err := lookup(ctx, name, qtype)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// cloudflareRanges are the address ranges Cloudflare serves proxied records
+// from (https://www.cloudflare.com/ips/)
+var cloudflareRanges = []string{
+	"173.245.48.0/20", "103.21.244.0/22", "103.22.200.0/22", "103.31.4.0/22",
+	"141.101.64.0/18", "108.162.192.0/18", "190.93.240.0/20", "188.114.96.0/20",
+	"197.234.240.0/22", "198.41.128.0/17", "162.158.0.0/15", "104.16.0.0/13",
+	"104.24.0.0/14", "172.64.0.0/13", "131.0.72.0/22",
+	"2400:cb00::/32", "2606:4700::/32", "2803:f800::/32", "2405:b500::/32",
+	"2405:8100::/32", "2a06:98c0::/29", "2c0f:f248::/32",
+}
+
+// IsCloudflareIP reports whether addr belongs to Cloudflare's proxy ranges
+func IsCloudflareIP(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, cidr := range cloudflareRanges {
+		if _, network, err := net.ParseCIDR(cidr); err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupCNAME returns the CNAME target of name, or "" if it has none
+func lookupCNAME(ctx context.Context, name string) (string, error) {
+	targets, err := lookup(ctx, name, dns.TypeCNAME)
+	if err != nil || len(targets) == 0 {
+		return "", err
+	}
+	return targets[0], nil
+}
+
+// lookup queries the first nameserver of /etc/resolv.conf for records of type
+// qtype at name, in the format Query returns. Systems without resolv.conf,
+// such as Windows, go through Go's resolver instead.
+func lookup(ctx context.Context, name string, qtype uint16) ([]string, error) {
+	typeName := dns.TypeToString[qtype]
+	conf, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+	if err != nil || len(conf.Servers) == 0 {
+		result, err := lookupDefault(ctx, name, qtype)
+		if err != nil {
+			return nil, fmt.Errorf("%s lookup for %s failed: %w", typeName, name, err)
+		}
+		return result, nil
+	}
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(name), qtype)
+	resp, err := exchange(ctx, msg, net.JoinHostPort(conf.Servers[0], conf.Port))
+	if err != nil {
+		return nil, fmt.Errorf("%s lookup for %s failed: %w", typeName, name, err)
+	}
+
+	var result []string
+	for _, rr := range resp.Answer {
+		if rr.Header().Rrtype != qtype {
+			continue
+		}
+		switch v := rr.(type) {
+		case *dns.A:
+			result = append(result, v.A.String())
+		case *dns.AAAA:
+			result = append(result, v.AAAA.String())
+		case *dns.CNAME:
+			result = append(result, Normalize(v.Target))
+		case *dns.MX:
+			result = append(result, Normalize(v.Mx))
+		case *dns.NS:
+			result = append(result, Normalize(v.Ns))
+		case *dns.PTR:
+			result = append(result, Normalize(v.Ptr))
+		case *dns.TXT:
+			result = append(result, strings.Join(v.Txt, ""))
+		default:
+			return nil, fmt.Errorf("live lookups are not supported for %s records", typeName)
+		}
+	}
+	return result, nil
+}
+
+// exchange sends msg to server, asking again over TCP if the UDP answer was
+// truncated. An answer with any rcode other than success is an error.
+func exchange(ctx context.Context, msg *dns.Msg, server string) (*dns.Msg, error) {
+	resp, _, err := new(dns.Client).ExchangeContext(ctx, msg, server)
+	if err == nil && resp.Truncated {
+		resp, _, err = (&dns.Client{Net: "tcp"}).ExchangeContext(ctx, msg, server)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("server answered %s", dns.RcodeToString[resp.Rcode])
+	}
+	return resp, nil
+}
+
+// lookupDefault is lookup through Go's resolver. It has no query for PTR
+// records by name, and its CNAME lookup returns the end of the chain.
+func lookupDefault(ctx context.Context, name string, qtype uint16) ([]string, error) {
+	r := net.DefaultResolver
+	var result []string
+	switch qtype {
+	case dns.TypeA, dns.TypeAAAA:
+		network := "ip4"
+		if qtype == dns.TypeAAAA {
+			network = "ip6"
+		}
+		ips, err := r.LookupIP(ctx, network, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			result = append(result, ip.String())
+		}
+	case dns.TypeCNAME:
+		target, err := r.LookupCNAME(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		// Go's resolver returns the name itself when it has no CNAME
+		if target = Normalize(target); target != Normalize(name) {
+			result = append(result, target)
+		}
+	case dns.TypeMX:
+		mxs, err := r.LookupMX(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, mx := range mxs {
+			result = append(result, Normalize(mx.Host))
+		}
+	case dns.TypeNS:
+		nss, err := r.LookupNS(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range nss {
+			result = append(result, Normalize(ns.Host))
+		}
+	case dns.TypeTXT:
+		txts, err := r.LookupTXT(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, txts...)
+	default:
+		return nil, fmt.Errorf("live lookups of %s records need a nameserver in /etc/resolv.conf", dns.TypeToString[qtype])
+	}
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package resolver
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+// startServer serves handler over UDP and TCP on the same local port and
+// returns its address
+func startServer(t *testing.T, handler dns.HandlerFunc) string {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := net.Listen("tcp", pc.LocalAddr().String())
+	if err != nil {
+		pc.Close()
+		t.Skipf("TCP port of %s is taken: %v", pc.LocalAddr(), err)
+	}
+	for _, srv := range []*dns.Server{{PacketConn: pc, Handler: handler}, {Listener: l, Handler: handler}} {
+		go srv.ActivateAndServe()
+		t.Cleanup(func() { srv.Shutdown() })
+	}
+	return pc.LocalAddr().String()
+}
+
+func TestExchangeTruncatedRetriesOverTCP(t *testing.T) {
+	server := startServer(t, func(w dns.ResponseWriter, req *dns.Msg) {
+		resp := new(dns.Msg)
+		resp.SetReply(req)
+		if w.RemoteAddr().Network() == "udp" {
+			resp.Truncated = true
+		} else {
+			resp.Answer = append(resp.Answer, &dns.TXT{
+				Hdr: dns.RR_Header{Name: req.Question[0].Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 60},
+				Txt: []string{"over tcp"},
+			})
+		}
+		w.WriteMsg(resp)
+	})
+
+	msg := new(dns.Msg)
+	msg.SetQuestion("example.com.", dns.TypeTXT)
+	resp, err := exchange(context.Background(), msg, server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Truncated || len(resp.Answer) != 1 {
+		t.Errorf("answer = %v, want the full answer over TCP", resp)
+	}
+}
+
+func TestExchangeRcodeError(t *testing.T) {
+	server := startServer(t, func(w dns.ResponseWriter, req *dns.Msg) {
+		resp := new(dns.Msg)
+		resp.SetRcode(req, dns.RcodeServerFailure)
+		w.WriteMsg(resp)
+	})
+
+	msg := new(dns.Msg)
+	msg.SetQuestion("example.com.", dns.TypeA)
+	_, err := exchange(context.Background(), msg, server)
+	if err == nil || !strings.Contains(err.Error(), "SERVFAIL") {
+		t.Errorf("exchange() error = %v, want SERVFAIL", err)
+	}
+}
@@ -0,0 +1,61 @@
+package version
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// checkInterval is how long a cached update check result is reused before
+// GitHub is asked again
+const checkInterval = 24 * time.Hour
+
+// checkCache is the last update check result, stored in ~/.cloudflare/update-check.json
+type checkCache struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest"`
+}
+
+// cachePath returns the update check cache file, or "" if there is no home directory
+func cachePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".cloudflare", "update-check.json")
+}
+
+// loadCheckCache reads the cache. A missing or unreadable cache is treated as empty.
+func loadCheckCache() checkCache {
+	var cache checkCache
+	path := cachePath()
+	if path == "" {
+		return cache
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &cache)
+	}
+	return cache
+}
+
+// fresh reports whether the cached result is recent enough to reuse
+func (c checkCache) fresh(now time.Time) bool {
+	return !c.CheckedAt.IsZero() && now.Sub(c.CheckedAt) < checkInterval && !c.CheckedAt.After(now)
+}
+
+// save writes the cache. Errors are ignored: the cache only saves network calls.
+func (c checkCache) save() {
+	path := cachePath()
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0600)
+}
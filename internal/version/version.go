@@ -20,7 +20,8 @@ func GetVersion() string {
 	return Version
 }
 
-// StartUpdateCheck begins an async check for newer versions.
+// StartUpdateCheck begins an async check for newer versions. The result is
+// cached in ~/.cloudflare/update-check.json and GitHub is only asked once a day.
 // Call PrintUpdateMessage after command execution to display any update notification.
 func StartUpdateCheck() {
 	updateMessage = make(chan string, 1)
@@ -34,11 +35,22 @@ func StartUpdateCheck() {
 	go func() {
 		defer close(updateMessage)
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		// Reuse the last result for a day instead of asking GitHub on every run
+		now := time.Now()
+		cache := loadCheckCache()
+		if !cache.fresh(now) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
 
-		latest, found, err := selfupdate.DetectLatest(ctx, selfupdate.ParseSlug("coollabsio/cloudflare-cli"))
-		if err != nil || !found {
+			latest, found, err := selfupdate.DetectLatest(ctx, selfupdate.ParseSlug("coollabsio/cloudflare-cli"))
+			if err == nil && found {
+				cache.Latest = latest.Version()
+			}
+			// Record failed checks too, so an offline machine isn't retried on every run
+			cache.CheckedAt = now
+			cache.save()
+		}
+		if cache.Latest == "" {
 			return
 		}
 
@@ -47,13 +59,13 @@ func StartUpdateCheck() {
 			return
 		}
 
-		latestVersion, err := goversion.NewVersion(latest.Version())
+		latestVersion, err := goversion.NewVersion(cache.Latest)
 		if err != nil {
 			return
 		}
 
 		if latestVersion.GreaterThan(currentVersion) {
-			updateMessage <- fmt.Sprintf("\nA new version (%s) is available. Update with: cf update\n", latest.Version())
+			updateMessage <- fmt.Sprintf("\nA new version (%s) is available. Update with: cf update\n", cache.Latest)
 		}
 	}()
 }
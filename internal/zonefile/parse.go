@@ -0,0 +1,234 @@
+package zonefile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+	"github.com/miekg/dns"
+)
+
+// Options controls which records are kept when converting parsed records
+type Options struct {
+	// IncludeApexNS keeps NS records at the zone apex (skipped by default,
+	// since Cloudflare manages the apex nameservers itself)
+	IncludeApexNS bool
+}
+
+// Parse reads a BIND zone file for the given zone and converts each record.
+// SOA records are always skipped; apex NS records are skipped unless opts.IncludeApexNS is set.
+func Parse(r io.Reader, zoneName string, opts Options) ([]client.CreateDNSRecordParams, error) {
+	origin := dns.Fqdn(zoneName)
+	zp := dns.NewZoneParser(r, origin, "")
+	zp.SetDefaultTTL(1)
+
+	var records []client.CreateDNSRecordParams
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		params, keep, err := convert(rr, origin, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", rr.Header().Name, err)
+		}
+		if !keep {
+			continue
+		}
+		applyComment(&params, zp.Comment())
+		records = append(records, params)
+	}
+	if err := zp.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse zone file: %w", err)
+	}
+	return records, nil
+}
+
+// Entry is a record read by ParseAll, with the error that kept it from being
+// converted, if any
+type Entry struct {
+	Params client.CreateDNSRecordParams
+	// Source is the record in zone file presentation format
+	Source string
+	Err    error
+}
+
+// ParseAll reads a zone file like Parse, but returns every record instead of
+// stopping at the first one that cannot be converted. A syntax error still
+// ends parsing and is returned.
+func ParseAll(r io.Reader, zoneName string, opts Options) ([]Entry, error) {
+	origin := dns.Fqdn(zoneName)
+	zp := dns.NewZoneParser(r, origin, "")
+	zp.SetDefaultTTL(1)
+
+	var entries []Entry
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		params, keep, err := convert(rr, origin, opts)
+		if err == nil && !keep {
+			continue
+		}
+		applyComment(&params, zp.Comment())
+		entries = append(entries, Entry{Params: params, Source: rr.String(), Err: err})
+	}
+	if err := zp.Err(); err != nil {
+		return entries, fmt.Errorf("failed to parse zone file: %w", err)
+	}
+	return entries, nil
+}
+
+// Transfer performs a zone transfer (AXFR) of zoneName from the given nameserver
+// and converts each record. The same skipping rules as Parse apply.
+func Transfer(server, zoneName string, opts Options) ([]client.CreateDNSRecordParams, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+
+	origin := dns.Fqdn(zoneName)
+	msg := new(dns.Msg)
+	msg.SetAxfr(origin)
+
+	t := new(dns.Transfer)
+	envelopes, err := t.In(msg, server)
+	if err != nil {
+		return nil, fmt.Errorf("zone transfer from %s failed: %w", server, err)
+	}
+
+	var records []client.CreateDNSRecordParams
+	for env := range envelopes {
+		if env.Error != nil {
+			return nil, fmt.Errorf("zone transfer from %s was refused or failed (the server may not allow AXFR from this host): %w", server, env.Error)
+		}
+		for _, rr := range env.RR {
+			params, keep, err := convert(rr, origin, opts)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", rr.Header().Name, err)
+			}
+			if keep {
+				records = append(records, params)
+			}
+		}
+	}
+	return records, nil
+}
+
+// Question is a name and record type asked for by Lookup
+type Question struct {
+	Name string
+	Type string
+}
+
+// Lookup asks the given nameserver directly (without recursion) for each
+// question and converts the answers like Transfer, for servers that refuse
+// AXFR. Only records at the asked names and types are found.
+func Lookup(ctx context.Context, server, zoneName string, questions []Question, opts Options) ([]client.CreateDNSRecordParams, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+
+	origin := dns.Fqdn(zoneName)
+	seen := make(map[Question]bool)
+	var records []client.CreateDNSRecordParams
+	for _, q := range questions {
+		q = Question{Name: strings.ToLower(dns.Fqdn(q.Name)), Type: strings.ToUpper(q.Type)}
+		qtype, ok := dns.StringToType[q.Type]
+		if !ok || seen[q] {
+			continue
+		}
+		seen[q] = true
+
+		msg := new(dns.Msg)
+		msg.SetQuestion(q.Name, qtype)
+		msg.RecursionDesired = false
+		resp, _, err := new(dns.Client).ExchangeContext(ctx, msg, server)
+		if err != nil {
+			return nil, fmt.Errorf("%s lookup for %s at %s failed: %w", q.Type, q.Name, server, err)
+		}
+		for _, rr := range resp.Answer {
+			if rr.Header().Rrtype != qtype || !strings.EqualFold(rr.Header().Name, q.Name) {
+				continue
+			}
+			params, keep, err := convert(rr, origin, opts)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", rr.Header().Name, err)
+			}
+			if keep {
+				records = append(records, params)
+			}
+		}
+	}
+	return records, nil
+}
+
+// convert maps a DNS resource record to Cloudflare create parameters.
+// It returns keep=false for records that should not be imported.
+func convert(rr dns.RR, origin string, opts Options) (client.CreateDNSRecordParams, bool, error) {
+	hdr := rr.Header()
+	name := strings.TrimSuffix(hdr.Name, ".")
+	params := client.CreateDNSRecordParams{
+		Type: dns.TypeToString[hdr.Rrtype],
+		Name: name,
+		TTL:  int(hdr.Ttl),
+	}
+	if params.TTL == 0 {
+		params.TTL = 1
+	}
+
+	switch v := rr.(type) {
+	case *dns.SOA:
+		return params, false, nil
+	case *dns.NS:
+		if hdr.Name == origin && !opts.IncludeApexNS {
+			return params, false, nil
+		}
+		params.Content = strings.TrimSuffix(v.Ns, ".")
+	case *dns.A:
+		params.Content = v.A.String()
+	case *dns.AAAA:
+		params.Content = v.AAAA.String()
+	case *dns.CNAME:
+		params.Content = strings.TrimSuffix(v.Target, ".")
+	case *dns.PTR:
+		params.Content = strings.TrimSuffix(v.Ptr, ".")
+	case *dns.MX:
+		params.Content = strings.TrimSuffix(v.Mx, ".")
+		params.Priority = &v.Preference
+	case *dns.SRV:
+		params.Content = fmt.Sprintf("%d %d %s", v.Weight, v.Port, strings.TrimSuffix(v.Target, "."))
+		params.Priority = &v.Priority
+	case *dns.TXT:
+		params.Content = joinTXT(v.Txt)
+	default:
+		// Use the presentation form of the RDATA for other types (CAA, etc.)
+		params.Content = strings.TrimSpace(strings.TrimPrefix(rr.String(), hdr.String()))
+	}
+
+	if params.Content == "" {
+		return params, false, fmt.Errorf("empty content for %s record", params.Type)
+	}
+	return params, true, nil
+}
+
+// joinTXT renders TXT strings as Cloudflare content: a single string as-is,
+// multiple strings quoted and space-separated
+func joinTXT(parts []string) string {
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	quoted := make([]string, len(parts))
+	for i, p := range parts {
+		quoted[i] = `"` + p + `"`
+	}
+	return strings.Join(quoted, " ")
+}
+
+// applyComment restores the Cloudflare settings that Write stores in trailing comments
+func applyComment(params *client.CreateDNSRecordParams, comment string) {
+	comment = strings.TrimSpace(strings.TrimPrefix(comment, ";"))
+	if comment == "" {
+		return
+	}
+	if rest, ok := strings.CutPrefix(comment, "cf-proxied:true"); ok {
+		params.Proxied = true
+		comment = strings.TrimSpace(rest)
+	}
+	params.Comment = comment
+}
@@ -0,0 +1,85 @@
+package zonefile
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/coollabsio/cloudflare-cli/internal/client"
+)
+
+// hostnameTypes are record types whose content is a hostname and is written fully qualified
+var hostnameTypes = map[string]bool{
+	"CNAME": true,
+	"MX":    true,
+	"NS":    true,
+	"PTR":   true,
+}
+
+// priorityTypes are record types whose priority is written before the content
+var priorityTypes = map[string]bool{
+	"MX":  true,
+	"SRV": true,
+	"URI": true,
+}
+
+// Write writes records in BIND zone file format for the given zone.
+// Cloudflare-specific settings (proxied, comment) are written as trailing comments.
+func Write(w io.Writer, zoneName string, records []client.DNSRecord) error {
+	if _, err := fmt.Fprintf(w, ";; Zone: %s\n$ORIGIN %s.\n\n", zoneName, strings.TrimSuffix(zoneName, ".")); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if _, err := fmt.Fprintln(w, FormatRecord(r)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FormatRecord formats a single record as a zone file line
+func FormatRecord(r client.DNSRecord) string {
+	recordType := strings.ToUpper(r.Type)
+
+	content := r.Content
+	switch {
+	case hostnameTypes[recordType]:
+		content = fqdn(content)
+	case recordType == "TXT" || recordType == "SPF":
+		content = quoteTXT(content)
+	}
+	if priorityTypes[recordType] && r.Priority != nil {
+		content = fmt.Sprintf("%d %s", *r.Priority, content)
+	}
+
+	line := fmt.Sprintf("%s\t%d\tIN\t%s\t%s", fqdn(r.Name), r.TTL, recordType, content)
+
+	var notes []string
+	if r.Proxied {
+		notes = append(notes, "cf-proxied:true")
+	}
+	if r.Comment != "" {
+		notes = append(notes, r.Comment)
+	}
+	if len(notes) > 0 {
+		line += " ; " + strings.Join(notes, " ")
+	}
+	return line
+}
+
+// fqdn returns the name with a trailing dot
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// quoteTXT wraps TXT content in quotes unless it is already quoted
+func quoteTXT(content string) string {
+	if strings.HasPrefix(content, `"`) && strings.HasSuffix(content, `"`) && len(content) > 1 {
+		return content
+	}
+	return `"` + strings.ReplaceAll(content, `"`, `\"`) + `"`
+}